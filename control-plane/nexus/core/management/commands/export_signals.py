@@ -0,0 +1,36 @@
+"""
+Management command that dumps every Signal in its published event shape.
+
+The output is a JSON array consumed by the data plane's
+`nexus-cli reconcile -source <file>` to detect drift between PostgreSQL
+and the Redis materialized view.
+"""
+
+import json
+
+from django.core.management.base import BaseCommand
+
+from nexus.core.models import Signal
+from nexus.core.signals import _signal_payload
+
+
+class Command(BaseCommand):
+    help = "Export all signals as a JSON array for data-plane reconciliation."
+
+    def add_arguments(self, parser):
+        parser.add_argument(
+            "--output",
+            help="Write the dump to this file instead of stdout.",
+        )
+
+    def handle(self, *args, **options):
+        signals = Signal.objects.select_related("author").order_by("-created_at")
+        dump = json.dumps([_signal_payload(signal) for signal in signals], indent=2)
+
+        if options["output"]:
+            with open(options["output"], "w", encoding="utf-8") as output:
+                output.write(dump)
+            self.stderr.write(f"Exported {signals.count()} signals to {options['output']}")
+            return
+
+        self.stdout.write(dump)
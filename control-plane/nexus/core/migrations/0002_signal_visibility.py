@@ -0,0 +1,18 @@
+# Generated by Django 6.0.1 on 2026-10-18 12:00
+
+from django.db import migrations, models
+
+
+class Migration(migrations.Migration):
+
+    dependencies = [
+        ('core', '0001_initial'),
+    ]
+
+    operations = [
+        migrations.AddField(
+            model_name='signal',
+            name='visibility',
+            field=models.CharField(choices=[('public', 'Public'), ('internal', 'Internal'), ('admin', 'Admin')], default='public', max_length=16),
+        ),
+    ]
@@ -18,6 +18,11 @@ class Signal(models.Model):
         MEDIUM = 2, 'Medium'
         HIGH = 3, 'High'
 
+    class Visibility(models.TextChoices):
+        PUBLIC = 'public', 'Public'
+        INTERNAL = 'internal', 'Internal'
+        ADMIN = 'admin', 'Admin'
+
     id = models.UUIDField(primary_key=True, default=uuid.uuid4, editable=False) # As data might originate from different places, I'll stick with UUIDs to prevent collision.
 
     author = models.ForeignKey(
@@ -34,6 +39,12 @@ class Signal(models.Model):
         default=Priority.LOW,
     )
 
+    visibility = models.CharField(
+        max_length=16,
+        choices=Visibility.choices,
+        default=Visibility.PUBLIC,
+    )
+
     created_at = models.DateTimeField(auto_now_add=True)
     updated_at = models.DateTimeField(auto_now=True)
 
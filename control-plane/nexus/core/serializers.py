@@ -16,4 +16,4 @@ class GroupSerializer(serializers.HyperlinkedModelSerializer):
 class SignalSerializer(serializers.ModelSerializer):
     class Meta:
         model = Signal
-        fields = ['url', 'title', 'content', 'priority', 'author', 'created_at', 'updated_at']
\ No newline at end of file
+        fields = ['url', 'title', 'content', 'priority', 'visibility', 'author', 'created_at', 'updated_at']
\ No newline at end of file
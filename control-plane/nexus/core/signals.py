@@ -2,9 +2,10 @@
 Django signal handlers for publishing Signal model events to Redpanda.
 
 These handlers listen to post_save and post_delete signals from the Signal model
-and publish corresponding events to the 'nexus.signals' topic. Each event includes
-an 'action' field indicating the CRUD operation type. Events are only published
-after the database transaction commits successfully.
+and publish corresponding events to the 'nexus.signals' topic. Each event is an
+envelope with a 'schema_version', an 'action' field indicating the CRUD operation
+type, the signal 'id' and, except for deletes, the signal fields under 'data'.
+Events are only published after the database transaction commits successfully.
 """
 
 import json
@@ -15,6 +16,15 @@ from django.dispatch import receiver
 from nexus.core.models import Signal
 from nexus.core.producers import get_producer
 
+try:
+    from opentelemetry import propagate
+except ImportError:  # tracing is optional in the control plane
+    propagate = None
+
+# Version of the event envelope; bump it and add an upcaster in the data plane
+# whenever the layout changes.
+SCHEMA_VERSION = 2
+
 
 def _publish(topic, key, payload):
     """
@@ -30,25 +40,62 @@ def _publish(topic, key, payload):
         topic=topic,
         key=str(key).encode("utf-8"),
         value=json.dumps(payload).encode("utf-8"),
+        headers=_trace_headers(),
     )
     producer.poll(0)
 
 
+def _trace_headers():
+    """
+    Builds the W3C trace context headers of a published event.
+    
+    When OpenTelemetry is installed and a span is active, the traceparent
+    header propagates it, so the data plane's spans join the request's trace.
+    Otherwise no header is sent and the data plane starts a new root span.
+    
+    Returns:
+        List of (key, value) Kafka headers, empty without an active span
+    """
+    if propagate is None:
+        return []
+    carrier = {}
+    propagate.inject(carrier)
+    if "traceparent" not in carrier:
+        return []
+    return [("traceparent", carrier["traceparent"])]
+
+
 def _signal_payload(instance):
     """
-    Serializes a Signal instance to a dictionary for event publishing.
+    Serializes a Signal instance to a flat dictionary including its ID.
     
     Args:
         instance: Signal model instance
         
     Returns:
-        Dictionary containing signal data
+        Dictionary containing the signal ID and data
     """
     return {
         "id": str(instance.id),
+        **_signal_data(instance),
+    }
+
+
+def _signal_data(instance):
+    """
+    Serializes the fields of a Signal instance carried under an event's 'data'.
+    
+    Args:
+        instance: Signal model instance
+        
+    Returns:
+        Dictionary containing signal data
+    """
+    return {
         "title": instance.title,
         "content": instance.content,
         "priority": instance.get_priority_display(),
+        "visibility": instance.visibility,
         "author": instance.author.username,
         "created_at": instance.created_at.isoformat(),
         "updated_at": instance.updated_at.isoformat(),
@@ -65,8 +112,10 @@ def publish_signal_save(sender, instance, created, **kwargs):
     """
     action = "created" if created else "updated"
     payload = {
+        "schema_version": SCHEMA_VERSION,
         "action": action,
-        **_signal_payload(instance)
+        "id": str(instance.id),
+        "data": _signal_data(instance),
     }
     
     transaction.on_commit(lambda: _publish("nexus.signals", instance.id, payload))
@@ -78,9 +127,10 @@ def publish_signal_delete(sender, instance, **kwargs):
     Publishes an event when a Signal is deleted.
     
     Publishes to 'nexus.signals' topic with action field set to 'deleted'
-    and minimal payload containing only the ID.
+    and minimal payload containing only the envelope fields and the ID.
     """
     payload = {
+        "schema_version": SCHEMA_VERSION,
         "action": "deleted",
         "id": str(instance.id)
     }
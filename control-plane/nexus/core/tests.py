@@ -6,7 +6,9 @@ proper payload structure and message publishing behavior.
 """
 
 import json
+from io import StringIO
 from unittest.mock import Mock, patch
+from django.core.management import call_command
 from django.test import TransactionTestCase
 from django.contrib.auth import get_user_model
 from nexus.core.models import Signal
@@ -58,18 +60,53 @@ class SignalEventPublishingTestCase(TransactionTestCase):
         
         # Verify payload structure
         payload = json.loads(call_kwargs['value'].decode('utf-8'))
+        self.assertEqual(payload['schema_version'], 2)
         self.assertEqual(payload['action'], 'created')
         self.assertEqual(payload['id'], str(signal.id))
-        self.assertEqual(payload['title'], 'Test Signal')
-        self.assertEqual(payload['content'], 'This is a test signal')
-        self.assertEqual(payload['priority'], 'High')
-        self.assertEqual(payload['author'], 'testuser')
-        self.assertIn('created_at', payload)
-        self.assertIn('updated_at', payload)
+        self.assertEqual(payload['data']['title'], 'Test Signal')
+        self.assertEqual(payload['data']['content'], 'This is a test signal')
+        self.assertEqual(payload['data']['priority'], 'High')
+        self.assertEqual(payload['data']['author'], 'testuser')
+        self.assertEqual(payload['data']['visibility'], 'public')
+        self.assertIn('created_at', payload['data'])
+        self.assertIn('updated_at', payload['data'])
         
         # Verify poll was called
         self.mock_producer.poll.assert_called_once_with(0)
 
+    def test_event_without_span_has_no_traceparent(self):
+        """Test that without an active span no made-up trace context is sent."""
+        Signal.objects.create(
+            title="Traced Signal",
+            content="Followed into the data plane",
+            priority=Signal.Priority.LOW,
+            author=self.user
+        )
+
+        call_kwargs = self.mock_producer.produce.call_args[1]
+        headers = dict(call_kwargs['headers'])
+
+        self.assertNotIn('traceparent', headers)
+
+    def test_event_propagates_current_span(self):
+        """Test that an active OpenTelemetry span is propagated as the traceparent."""
+        traceparent = '00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'
+        propagator = Mock()
+        propagator.inject.side_effect = lambda carrier: carrier.update(traceparent=traceparent)
+
+        with patch('nexus.core.signals.propagate', propagator):
+            Signal.objects.create(
+                title="Traced Signal",
+                content="Part of the request's trace",
+                priority=Signal.Priority.LOW,
+                author=self.user
+            )
+
+        call_kwargs = self.mock_producer.produce.call_args[1]
+        headers = dict(call_kwargs['headers'])
+
+        self.assertEqual(headers['traceparent'], traceparent)
+
     def test_signal_update_publishes_updated_event(self):
         """Test that updating a Signal publishes an event with action='updated'."""
         signal = Signal.objects.create(
@@ -99,8 +136,8 @@ class SignalEventPublishingTestCase(TransactionTestCase):
         # Verify payload action is 'updated'
         payload = json.loads(call_kwargs['value'].decode('utf-8'))
         self.assertEqual(payload['action'], 'updated')
-        self.assertEqual(payload['title'], 'Updated Title')
-        self.assertEqual(payload['priority'], 'Medium')
+        self.assertEqual(payload['data']['title'], 'Updated Title')
+        self.assertEqual(payload['data']['priority'], 'Medium')
 
     def test_signal_deletion_publishes_deleted_event(self):
         """Test that deleting a Signal publishes an event with action='deleted'."""
@@ -135,8 +172,9 @@ class SignalEventPublishingTestCase(TransactionTestCase):
         payload = json.loads(call_kwargs['value'].decode('utf-8'))
         self.assertEqual(payload['action'], 'deleted')
         self.assertEqual(payload['id'], str(signal_id))
-        # Deleted events should only contain action and id
-        self.assertEqual(len(payload), 2)
+        # Deleted events should only contain schema_version, action and id
+        self.assertEqual(payload['schema_version'], 2)
+        self.assertEqual(len(payload), 3)
 
     def test_signal_priority_display_values(self):
         """Test that priority values are human-readable strings."""
@@ -160,8 +198,8 @@ class SignalEventPublishingTestCase(TransactionTestCase):
                 payload = json.loads(call_kwargs['value'].decode('utf-8'))
                 
                 # Verify priority is the display string, not the integer
-                self.assertEqual(payload['priority'], expected_display)
-                self.assertNotEqual(payload['priority'], priority_value)
+                self.assertEqual(payload['data']['priority'], expected_display)
+                self.assertNotEqual(payload['data']['priority'], priority_value)
                 
                 # Clean up
                 signal.delete()
@@ -204,11 +242,11 @@ class SignalEventPublishingTestCase(TransactionTestCase):
         payload = json.loads(call_kwargs['value'].decode('utf-8'))
         
         # Verify author is username string
-        self.assertEqual(payload['author'], 'testuser')
-        self.assertIsInstance(payload['author'], str)
+        self.assertEqual(payload['data']['author'], 'testuser')
+        self.assertIsInstance(payload['data']['author'], str)
         # Ensure it's not the ID
-        self.assertNotEqual(payload['author'], self.user.id)
-        self.assertNotIn('author_id', payload)
+        self.assertNotEqual(payload['data']['author'], self.user.id)
+        self.assertNotIn('author_id', payload['data'])
 
     def test_transaction_commit_behavior(self):
         """Test that events are only published after transaction commits."""
@@ -224,3 +262,48 @@ class SignalEventPublishingTestCase(TransactionTestCase):
             mock_on_commit.assert_called_once()
             callback = mock_on_commit.call_args[0][0]
             self.assertTrue(callable(callback))
+
+
+class ExportSignalsCommandTestCase(TransactionTestCase):
+    """Tests for the export_signals reconciliation dump."""
+
+    def setUp(self):
+        """Create test user and silence event publishing."""
+        self.user = User.objects.create_user(
+            username="testuser",
+            email="test@example.com",
+            password="testpass123"
+        )
+        self.patcher = patch('nexus.core.signals.get_producer', return_value=Mock())
+        self.patcher.start()
+
+    def tearDown(self):
+        """Clean up mocks."""
+        self.patcher.stop()
+
+    def test_export_matches_event_payload(self):
+        """Test that each exported signal has the same fields as its published event."""
+        signal = Signal.objects.create(
+            title="Exported Signal",
+            content="Included in the dump",
+            priority=Signal.Priority.HIGH,
+            author=self.user
+        )
+
+        output = StringIO()
+        call_command('export_signals', stdout=output)
+        dump = json.loads(output.getvalue())
+
+        self.assertEqual(len(dump), 1)
+        self.assertEqual(dump[0]['id'], str(signal.id))
+        self.assertEqual(dump[0]['priority'], 'High')
+        self.assertEqual(dump[0]['author'], 'testuser')
+        self.assertEqual(dump[0]['visibility'], 'public')
+        self.assertNotIn('action', dump[0])
+
+    def test_export_empty(self):
+        """Test that an empty database exports an empty array."""
+        output = StringIO()
+        call_command('export_signals', stdout=output)
+
+        self.assertEqual(json.loads(output.getvalue()), [])
@@ -1,15 +1,28 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/client"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/reconcile"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/snapshot"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tlsconfig"
+	"github.com/redis/go-redis/v9"
 )
 
 const (
@@ -18,6 +31,8 @@ const (
 	colorGreen  = "\033[32m"
 	colorYellow = "\033[33m"
 	colorBold   = "\033[1m"
+
+	barWidth = 30
 )
 
 func main() {
@@ -27,15 +42,23 @@ func main() {
 	}
 
 	apiURL := envOrDefault("API_URL", "http://localhost:8081")
-	dataPlane := client.New(apiURL)
+	dataPlane := client.New(apiURL, apiOptions()...)
 
 	switch os.Args[1] {
 	case "list":
 		runList(dataPlane)
 	case "get":
 		runGet(dataPlane)
+	case "stats":
+		runStats(dataPlane)
+	case "snapshot":
+		runSnapshot()
+	case "reconcile":
+		runReconcile()
 	case "health":
 		runHealth(dataPlane)
+	case "apikey":
+		runAPIKey()
 	default:
 		printUsage()
 		os.Exit(1)
@@ -45,11 +68,13 @@ func main() {
 func runList(dataPlane client.DataPlane) {
 	flags := flag.NewFlagSet("list", flag.ExitOnError)
 	priority := flags.String("priority", "", "Filter by priority (Low, Medium, High)")
+	fieldList := flags.String("fields", "", "Comma-separated fields to show, e.g. id,title,priority")
 	if err := flags.Parse(os.Args[2:]); err != nil {
 		exitWithError(err)
 	}
+	fields := parseFields(*fieldList)
 
-	signals, err := dataPlane.ListSignals(*priority)
+	signals, err := dataPlane.WithFields(fields...).ListSignals(*priority)
 	if err != nil {
 		exitWithError(err)
 	}
@@ -58,23 +83,29 @@ func runList(dataPlane client.DataPlane) {
 		fmt.Println("No signals found.")
 		return
 	}
+	if fields != nil {
+		printFieldTable(signals, fields)
+		return
+	}
 	printSignalTable(signals)
 }
 
 func runGet(dataPlane client.DataPlane) {
 	flags := flag.NewFlagSet("get", flag.ExitOnError)
+	fieldList := flags.String("fields", "", "Comma-separated fields to show, e.g. id,title,priority")
 	if err := flags.Parse(os.Args[2:]); err != nil {
 		exitWithError(err)
 	}
+	fields := parseFields(*fieldList)
 
 	args := flags.Args()
 	if len(args) == 0 {
 		fmt.Fprintln(os.Stderr, "Error: signal ID is required")
-		fmt.Fprintln(os.Stderr, "Usage: nexus-cli get <signal-id>")
+		fmt.Fprintln(os.Stderr, "Usage: nexus-cli get [-fields id,title] <signal-id>")
 		os.Exit(1)
 	}
 
-	signal, err := dataPlane.GetSignal(args[0])
+	signal, err := dataPlane.WithFields(fields...).GetSignal(args[0])
 	if errors.Is(err, client.ErrNotFound) {
 		fmt.Fprintf(os.Stderr, "Signal %q not found.\n", args[0])
 		os.Exit(1)
@@ -82,9 +113,23 @@ func runGet(dataPlane client.DataPlane) {
 	if err != nil {
 		exitWithError(err)
 	}
+	if fields != nil {
+		printFieldDetail(signal, fields)
+		return
+	}
 	printSignalDetail(signal)
 }
 
+// parseFields parses a -fields flag, exiting on unknown fields. An empty
+// flag yields nil: every field, printed in the usual layout.
+func parseFields(value string) []string {
+	fields, err := domain.ParseFields(value)
+	if err != nil {
+		exitWithError(fmt.Errorf("invalid -fields: %w", err))
+	}
+	return fields
+}
+
 func runHealth(dataPlane client.DataPlane) {
 	err := dataPlane.Health()
 	if err != nil {
@@ -94,6 +139,294 @@ func runHealth(dataPlane client.DataPlane) {
 	fmt.Printf("%s✓ Data Plane is healthy%s\n", colorGreen, colorReset)
 }
 
+func runAPIKey() {
+	key, err := auth.GenerateKey()
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("%sKey:%s     %s\n", colorBold, colorReset, key)
+	fmt.Printf("%sSHA-256:%s %s\n", colorBold, colorReset, auth.HashKey(key))
+	fmt.Println()
+	fmt.Println("Add the SHA-256 to the server's auth.api_keys (or AUTH_API_KEYS) and hand")
+	fmt.Println("the key to the client as API_TOKEN. The key itself is not stored anywhere.")
+}
+
+func runStats(dataPlane client.DataPlane) {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	from := flags.String("from", "", "Count signals created on or after this day (YYYY-MM-DD)")
+	to := flags.String("to", "", "Count signals created on or before this day (YYYY-MM-DD)")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		exitWithError(err)
+	}
+
+	stats, err := dataPlane.Stats(*from, *to)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if stats.Total == 0 {
+		fmt.Println("No signals found.")
+		return
+	}
+	printStats(stats)
+}
+
+func runSnapshot() {
+	if len(os.Args) < 3 {
+		printSnapshotUsage()
+		os.Exit(1)
+	}
+	switch os.Args[2] {
+	case "export":
+		runSnapshotExport()
+	case "import":
+		runSnapshotImport()
+	default:
+		printSnapshotUsage()
+		os.Exit(1)
+	}
+}
+
+func runSnapshotExport() {
+	flags := flag.NewFlagSet("snapshot export", flag.ExitOnError)
+	output := flags.String("o", "", "Write the snapshot to this file (default: stdout)")
+	if err := flags.Parse(os.Args[3:]); err != nil {
+		exitWithError(err)
+	}
+
+	ctx := context.Background()
+	proj, redisClient := connectProjection(ctx)
+	defer func() { _ = redisClient.Close() }()
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			exitWithError(err)
+		}
+		defer func() { _ = file.Close() }()
+		writer = file
+	}
+
+	count, err := snapshot.Export(ctx, proj, writer)
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Fprintf(os.Stderr, "%s✓ Exported %d keys%s\n", colorGreen, count, colorReset)
+}
+
+func runSnapshotImport() {
+	flags := flag.NewFlagSet("snapshot import", flag.ExitOnError)
+	input := flags.String("i", "", "Read the snapshot from this file (default: stdin)")
+	if err := flags.Parse(os.Args[3:]); err != nil {
+		exitWithError(err)
+	}
+
+	ctx := context.Background()
+	proj, redisClient := connectProjection(ctx)
+	defer func() { _ = redisClient.Close() }()
+
+	var reader io.Reader = os.Stdin
+	if *input != "" {
+		file, err := os.Open(*input)
+		if err != nil {
+			exitWithError(err)
+		}
+		defer func() { _ = file.Close() }()
+		reader = file
+	}
+
+	header, count, err := snapshot.Import(ctx, proj, reader)
+	if errors.Is(err, projection.ErrNotEmpty) {
+		exitWithError(fmt.Errorf("%w: import requires a fresh Redis keyspace", err))
+	}
+	if err != nil {
+		exitWithError(err)
+	}
+	fmt.Printf("%s✓ Imported %d keys from snapshot taken at %s%s\n", colorGreen, count, header.CreatedAt, colorReset)
+	for _, position := range header.Positions {
+		fmt.Printf("  %s[%d] resumes after offset %d\n", position.Topic, position.Partition, position.Offset)
+	}
+	fmt.Println("Consumers started against this Redis resume from these positions.")
+}
+
+func runReconcile() {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	source := flags.String("source", "", "Control-plane dump: a JSON file path or an http(s) URL")
+	repair := flags.Bool("repair", false, "Apply the source of truth to the projection")
+	createMissing := flags.Bool("create-missing", false, "With -repair, also create signals missing from the projection, even if deleted after the dump")
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		exitWithError(err)
+	}
+	if *source == "" {
+		fmt.Fprintln(os.Stderr, "Error: -source is required")
+		fmt.Fprintln(os.Stderr, "Usage: nexus-cli reconcile -source <file|url> [-repair [-create-missing]]")
+		os.Exit(1)
+	}
+	options, err := repairOptions(*createMissing)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	expected, err := loadDump(*source)
+	if err != nil {
+		exitWithError(fmt.Errorf("loading control-plane dump: %w", err))
+	}
+
+	ctx := context.Background()
+	proj, redisClient := connectProjection(ctx)
+	defer func() { _ = redisClient.Close() }()
+
+	actual, err := proj.ListByCreatedAt(ctx, domain.VisibilityAdmin, 0, -1)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	report := reconcile.Compare(expected, actual)
+	printReconcileReport(report)
+	if report.InSync() {
+		return
+	}
+	if !*repair {
+		fmt.Println("\nRun again with -repair to apply the control-plane state.")
+		os.Exit(1)
+	}
+
+	repaired, err := reconcile.Repair(ctx, proj, report, options...)
+	if err != nil {
+		exitWithError(fmt.Errorf("repaired %d signals before failing: %w", repaired, err))
+	}
+	fmt.Printf("\n%s✓ Repaired %d signals%s\n", colorGreen, repaired, colorReset)
+	if len(report.Missing) > 0 && !*createMissing {
+		fmt.Println("Missing signals were left alone, as they may have been deleted after the dump; run with -create-missing to create them.")
+		os.Exit(1)
+	}
+}
+
+// repairOptions creates missing signals when asked to, and leaves alone the
+// signals older than RETENTION_MAX_AGE, which retention would evict.
+func repairOptions(createMissing bool) ([]reconcile.RepairOption, error) {
+	var options []reconcile.RepairOption
+	if createMissing {
+		options = append(options, reconcile.WithCreateMissing())
+	}
+	if value := os.Getenv("RETENTION_MAX_AGE"); value != "" {
+		maxAge, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("RETENTION_MAX_AGE: %w", err)
+		}
+		if maxAge > 0 {
+			options = append(options, reconcile.WithRetentionHorizon(time.Now().Add(-maxAge)))
+		}
+	}
+	return options, nil
+}
+
+// dumpTimeout bounds the download of a control-plane dump.
+const dumpTimeout = 30 * time.Second
+
+func loadDump(source string) ([]domain.Signal, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		client := &http.Client{Timeout: dumpTimeout}
+		response, err := client.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = response.Body.Close() }()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status: %d", response.StatusCode)
+		}
+		return reconcile.Load(response.Body)
+	}
+
+	file, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+	return reconcile.Load(file)
+}
+
+func printReconcileReport(report reconcile.Report) {
+	fmt.Printf("%sChecked:%s %d signals from the control plane\n", colorBold, colorReset, report.Checked)
+	if len(report.Newer) > 0 {
+		fmt.Printf("%sNewer:%s %d signals changed after the dump, left alone\n", colorBold, colorReset, len(report.Newer))
+	}
+	if report.InSync() {
+		fmt.Printf("%s✓ Projection is in sync%s\n", colorGreen, colorReset)
+		return
+	}
+	fmt.Printf("%sMissing:%s %d   %sExtra:%s %d   %sStale:%s %d\n\n",
+		colorBold, colorReset, len(report.Missing),
+		colorBold, colorReset, len(report.Extra),
+		colorBold, colorReset, len(report.Stale),
+	)
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "%sDRIFT\tID\tDETAILS%s\n", colorBold, colorReset)
+	for _, signal := range report.Missing {
+		_, _ = fmt.Fprintf(writer, "%smissing%s\t%s\t%s\n", colorRed, colorReset, signal.ID, truncate(signal.Title, 40))
+	}
+	for _, signal := range report.Extra {
+		_, _ = fmt.Fprintf(writer, "%sextra%s\t%s\t%s\n", colorYellow, colorReset, signal.ID, truncate(signal.Title, 40))
+	}
+	for _, stale := range report.Stale {
+		for _, field := range stale.Fields {
+			_, _ = fmt.Fprintf(writer, "%sstale%s\t%s\t%s: %q → %q\n", colorYellow, colorReset,
+				stale.Expected.ID, field.Field, truncate(field.Actual, 30), truncate(field.Expected, 30))
+		}
+	}
+	_ = writer.Flush()
+}
+
+// apiOptions authenticates with API_TOKEN and configures TLS towards the
+// API from API_CA_FILE, and API_CERT_FILE with API_KEY_FILE for mutual TLS.
+func apiOptions() []client.Option {
+	var options []client.Option
+	if token := os.Getenv("API_TOKEN"); token != "" {
+		options = append(options, client.WithToken(token))
+	}
+	tlsOptions := tlsconfig.Options{
+		CAFile:   os.Getenv("API_CA_FILE"),
+		CertFile: os.Getenv("API_CERT_FILE"),
+		KeyFile:  os.Getenv("API_KEY_FILE"),
+	}
+	if tlsOptions == (tlsconfig.Options{}) {
+		return options
+	}
+	tlsConfig, err := tlsconfig.Client(tlsOptions)
+	if err != nil {
+		exitWithError(fmt.Errorf("API TLS configuration: %w", err))
+	}
+	return append(options, client.WithTLSConfig(tlsConfig))
+}
+
+// connectProjection opens a direct Redis connection for commands that work
+// on the projection itself rather than through the HTTP API.
+func connectProjection(ctx context.Context) (projection.SignalProjection, *redis.Client) {
+	options := &redis.Options{
+		Addr:     envOrDefault("REDIS_ADDR", "localhost:6379"),
+		Username: os.Getenv("REDIS_USERNAME"),
+		Password: os.Getenv("REDIS_PASSWORD"),
+	}
+	if enabled, _ := strconv.ParseBool(os.Getenv("REDIS_TLS_ENABLED")); enabled {
+		tlsConfig, err := tlsconfig.Client(tlsconfig.Options{
+			CAFile:   os.Getenv("REDIS_TLS_CA_FILE"),
+			CertFile: os.Getenv("REDIS_TLS_CERT_FILE"),
+			KeyFile:  os.Getenv("REDIS_TLS_KEY_FILE"),
+		})
+		if err != nil {
+			exitWithError(fmt.Errorf("redis TLS configuration: %w", err))
+		}
+		options.TLSConfig = tlsConfig
+	}
+	redisClient := redis.NewClient(options)
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		exitWithError(fmt.Errorf("redis connection failed: %w", err))
+	}
+	return projection.New(redisClient), redisClient
+}
+
 func printSignalTable(signals []domain.Signal) {
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	_, _ = fmt.Fprintf(writer, "%sID\tPRIORITY\tAUTHOR\tTITLE\tCREATED%s\n", colorBold, colorReset)
@@ -122,6 +455,134 @@ func printSignalDetail(signal domain.Signal) {
 	fmt.Printf("%sUpdated:%s   %s\n", colorBold, colorReset, signal.UpdatedAt)
 }
 
+// printFieldTable prints a table with one column per field, in the order
+// given.
+func printFieldTable(signals []domain.Signal, fields []string) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "%s%s%s\n", colorBold, strings.ToUpper(strings.Join(fields, "\t")), colorReset)
+
+	for _, signal := range signals {
+		values := signal.Select(fields)
+		cells := make([]string, len(fields))
+		for index, field := range fields {
+			cells[index] = formatField(signal, field, values[field])
+		}
+		_, _ = fmt.Fprintln(writer, strings.Join(cells, "\t"))
+	}
+	_ = writer.Flush()
+}
+
+// printFieldDetail prints one line per field, in the order given, unabridged
+// as printSignalDetail does.
+func printFieldDetail(signal domain.Signal, fields []string) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	values := signal.Select(fields)
+	for _, field := range fields {
+		value := values[field]
+		if field == "priority" {
+			value = priorityColor(value) + value + colorReset
+		}
+		_, _ = fmt.Fprintf(writer, "%s%s:%s\t%s\n", colorBold, field, colorReset, value)
+	}
+	_ = writer.Flush()
+}
+
+// formatField renders a field's value as printSignalTable would.
+func formatField(signal domain.Signal, field, value string) string {
+	switch field {
+	case "priority":
+		return priorityColor(signal.Priority) + value + colorReset
+	case "title":
+		return truncate(value, 40)
+	case "created_at", "updated_at":
+		return formatTime(value)
+	}
+	return value
+}
+
+// countRow is a single labelled line in a stats bar chart.
+type countRow struct {
+	label string
+	color string
+	count int64
+}
+
+func printStats(stats domain.Stats) {
+	fmt.Printf("%sTotal signals:%s %d\n", colorBold, colorReset, stats.Total)
+	printCountChart("PRIORITY", priorityRows(stats.ByPriority))
+	printCountChart("AUTHOR", authorRows(stats.ByAuthor))
+	printCountChart("DAY", dayRows(stats.ByDay))
+}
+
+func printCountChart(title string, rows []countRow) {
+	if len(rows) == 0 {
+		return
+	}
+	var largest int64
+	for _, row := range rows {
+		largest = max(largest, row.count)
+	}
+
+	fmt.Println()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "%s%s\tCOUNT\t%s\n", colorBold, title, colorReset)
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(writer, "%s%s%s\t%d\t%s%s%s\n",
+			row.color, row.label, colorReset,
+			row.count,
+			row.color, bar(row.count, largest), colorReset,
+		)
+	}
+	_ = writer.Flush()
+}
+
+func priorityRows(counts map[string]int64) []countRow {
+	rows := make([]countRow, 0, len(counts))
+	for _, priority := range slices.Backward(domain.Priorities()) {
+		if count, ok := counts[priority]; ok {
+			rows = append(rows, countRow{label: priority, color: priorityColor(priority), count: count})
+		}
+	}
+	for priority, count := range counts {
+		if priorityColor(priority) == colorReset {
+			rows = append(rows, countRow{label: priority, color: colorReset, count: count})
+		}
+	}
+	return rows
+}
+
+func authorRows(counts map[string]int64) []countRow {
+	rows := make([]countRow, 0, len(counts))
+	for author, count := range counts {
+		rows = append(rows, countRow{label: author, color: colorReset, count: count})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].count != rows[j].count {
+			return rows[i].count > rows[j].count
+		}
+		return rows[i].label < rows[j].label
+	})
+	return rows
+}
+
+func dayRows(days []domain.DayCount) []countRow {
+	rows := make([]countRow, 0, len(days))
+	for _, day := range days {
+		rows = append(rows, countRow{label: day.Day, color: colorReset, count: day.Count})
+	}
+	return rows
+}
+
+// bar renders count as a block bar scaled against largest. Non-zero counts
+// always get at least one block.
+func bar(count, largest int64) string {
+	if largest <= 0 || count <= 0 {
+		return ""
+	}
+	width := int(count * barWidth / largest)
+	return strings.Repeat("█", max(width, 1))
+}
+
 func printUsage() {
 	fmt.Printf("%snexus-cli%s — Nexus Data Plane client\n\n", colorBold, colorReset)
 	fmt.Println("Usage: nexus-cli <command> [flags]")
@@ -129,16 +590,44 @@ func printUsage() {
 	fmt.Printf("%sCommands:%s\n", colorBold, colorReset)
 	fmt.Println("  list      List signals")
 	fmt.Println("  get       Get a signal by ID")
+	fmt.Println("  stats     Show signal counts per priority, author and day")
+	fmt.Println("  snapshot  Export or import the projection (export|import)")
+	fmt.Println("  reconcile Compare the projection with a control-plane dump")
 	fmt.Println("  health    Check data-plane health")
+	fmt.Println("  apikey    Generate an API key and the hash the server stores")
 	fmt.Println()
 	fmt.Printf("%sExamples:%s\n", colorBold, colorReset)
 	fmt.Println("  nexus-cli list")
 	fmt.Println("  nexus-cli list -priority High")
+	fmt.Println("  nexus-cli list -fields id,title,priority")
 	fmt.Println("  nexus-cli get 550e8400-e29b-41d4-a716-446655440000")
+	fmt.Println("  nexus-cli get -fields title,content 550e8400-e29b-41d4-a716-446655440000")
+	fmt.Println("  nexus-cli stats -from 2026-02-01 -to 2026-02-28")
+	fmt.Println("  nexus-cli snapshot export -o projection.ndjson")
+	fmt.Println("  nexus-cli snapshot import -i projection.ndjson")
+	fmt.Println("  nexus-cli reconcile -source signals.json -repair")
 	fmt.Println("  nexus-cli health")
+	fmt.Println("  nexus-cli apikey")
 	fmt.Println()
 	fmt.Printf("%sEnvironment:%s\n", colorBold, colorReset)
-	fmt.Println("  API_URL   Data plane base URL (default: http://localhost:8081)")
+	fmt.Println("  API_URL         Data plane base URL (default: http://localhost:8081)")
+	fmt.Println("  API_TOKEN       API key or JWT for APIs requiring authentication")
+	fmt.Println("  API_CA_FILE     CA bundle trusted for an https API_URL")
+	fmt.Println("  API_CERT_FILE, API_KEY_FILE")
+	fmt.Println("                  Client certificate for APIs requiring mutual TLS")
+	fmt.Println("  REDIS_ADDR      Redis address for snapshot and reconcile (default: localhost:6379)")
+	fmt.Println("  REDIS_USERNAME, REDIS_PASSWORD, REDIS_TLS_ENABLED, REDIS_TLS_CA_FILE,")
+	fmt.Println("  REDIS_TLS_CERT_FILE, REDIS_TLS_KEY_FILE")
+	fmt.Println("                  Redis credentials and TLS, as for the server")
+	fmt.Println("  RETENTION_MAX_AGE")
+	fmt.Println("                  Retention age; reconcile -repair leaves older signals alone")
+}
+
+func printSnapshotUsage() {
+	fmt.Println("Usage: nexus-cli snapshot <export|import> [flags]")
+	fmt.Println()
+	fmt.Println("  export [-o file]   Write the projection and its applied positions as NDJSON")
+	fmt.Println("  import [-i file]   Load a snapshot into an empty Redis; consumers resume from its positions")
 }
 
 func priorityColor(priority string) string {
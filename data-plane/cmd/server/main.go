@@ -2,103 +2,401 @@ package main
 
 import (
 	"context"
-	"log"
+	"crypto/tls"
+	"expvar"
+	"flag"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/config"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/consumer"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/logging"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/registry"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/retention"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tlsconfig"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tracing"
 	"github.com/redis/go-redis/v9"
 	"github.com/segmentio/kafka-go"
 )
 
 func main() {
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML configuration file")
+	role := flag.String("role", "", "components to run: all, consumer or api (overrides ROLE)")
+	printConfig := flag.Bool("print-config", false, "print the effective configuration with secrets masked and exit")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath, os.Getenv)
+	if err == nil && *role != "" {
+		cfg.Role = *role
+		err = cfg.Validate()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		os.Exit(2)
+	}
+	if *printConfig {
+		printEffective(cfg)
+		return
+	}
+
+	setupLogging(cfg.Logging)
+	os.Exit(run(cfg))
+}
+
+// run starts the components of the configured role and blocks until they
+// have shut down. Shutdown starts on SIGINT or SIGTERM and is bounded by
+// cfg.ShutdownTimeout; past it, whatever is still running is abandoned and
+// run returns a non-zero exit code.
+func run(cfg config.Config) int {
 	ctx := setupContext()
+	abort := abortAfter(ctx, cfg.ShutdownTimeout)
+	slog.Info("starting", "role", cfg.Role, "shutdown_timeout", cfg.ShutdownTimeout)
 
-	redisClient := connectRedis(ctx)
+	redisClient := connectRedis(ctx, cfg.Redis)
 	defer func() {
 		if err := redisClient.Close(); err != nil {
-			log.Printf("redis close error: %v", err)
+			slog.Error("redis close failed", "error", err)
+		}
+	}()
+
+	shutdownTracing := setupTracing(cfg.Tracing)
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("tracing shutdown failed", "error", err)
 		}
 	}()
 
 	proj := projection.New(redisClient)
 
-	startConsumer(ctx, proj)
-	serveHTTP(ctx, proj)
+	var background workers
+	if cfg.Consumes() {
+		rebuildStats(ctx, proj)
+		migrateVisibility(ctx, proj)
+		startConsumer(ctx, abort, &background, proj, cfg.Kafka, cfg.Consumer)
+		startSweeper(ctx, &background, proj, cfg.Retention)
+	}
+	drained := serveHTTP(ctx, abort, &background, redisClient, proj, cfg)
+
+	if abandoned := background.Wait(abort); len(abandoned) > 0 {
+		slog.Error("shutdown deadline exceeded, abandoning workers", "workers", abandoned)
+		return 1
+	}
+	if !drained {
+		return 1
+	}
+	slog.Info("shutdown complete", "role", cfg.Role)
+	return 0
+}
+
+func printEffective(cfg config.Config) {
+	content, err := cfg.Redacted().YAML()
+	if err != nil {
+		fatal("rendering configuration failed", "error", err)
+	}
+	if _, err := os.Stdout.Write(content); err != nil {
+		fatal("printing configuration failed", "error", err)
+	}
 }
 
+// setupContext returns a context cancelled by the first SIGINT or SIGTERM.
+// Signal handling is then reset, so a second signal kills the process.
 func setupContext() context.Context {
-	ctx, _ := signal.NotifyContext(
+	ctx, stop := signal.NotifyContext(
 		context.Background(),
 		syscall.SIGINT,
 		syscall.SIGTERM,
 	)
+	context.AfterFunc(ctx, stop)
 	return ctx
 }
 
-func connectRedis(ctx context.Context) *redis.Client {
+// abortAfter returns a context cancelled timeout after ctx is: the deadline
+// for draining in-flight work once shutdown has started.
+func abortAfter(ctx context.Context, timeout time.Duration) context.Context {
+	abort, cancel := context.WithCancel(context.Background())
+	context.AfterFunc(ctx, func() {
+		time.AfterFunc(timeout, cancel)
+	})
+	return abort
+}
+
+func setupLogging(cfg config.Logging) {
+	if _, err := logging.Setup(os.Stderr, cfg.Level, cfg.Format); err != nil {
+		fatal("invalid logging configuration", "error", err)
+	}
+}
+
+func setupTracing(cfg config.Tracing) func(context.Context) error {
+	shutdown, err := tracing.Setup(tracing.Config{Exporter: cfg.Exporter, File: cfg.File})
+	if err != nil {
+		fatal("tracing setup failed", "error", err)
+	}
+	slog.Info("tracing configured", "exporter", cfg.Exporter)
+	return shutdown
+}
+
+func connectRedis(ctx context.Context, cfg config.Redis) *redis.Client {
+	tlsConfig, err := cfg.TLS.Build()
+	if err != nil {
+		fatal("invalid redis TLS configuration", "error", err)
+	}
 	client := redis.NewClient(&redis.Options{
-		Addr: envOrDefault("REDIS_ADDR", "localhost:6379"),
+		Addr:      cfg.Addr,
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+		DB:        cfg.DB,
+		TLSConfig: tlsConfig,
 	})
 	pingResult := client.Ping(ctx)
 	if err := pingResult.Err(); err != nil {
-		log.Fatalf("redis connection failed: %v", err)
+		fatal("redis connection failed", "error", err)
 	}
-	log.Println("connected to redis")
+	slog.Info("connected to redis")
 	return client
 }
 
-func startConsumer(ctx context.Context, proj projection.SignalProjection) {
+// rebuildStats recounts the stats counters once, so signals projected before
+// they existed are counted. A failure leaves those signals uncounted and is
+// retried on the next start.
+func rebuildStats(ctx context.Context, proj projection.SignalProjection) {
+	counted, err := proj.RebuildStats(ctx)
+	if err != nil {
+		slog.Warn("stats rebuild failed, older signals stay uncounted", "error", err)
+		return
+	}
+	if counted > 0 {
+		slog.Info("stats rebuild complete", "signals", counted)
+	}
+}
+
+// migrateVisibility backfills signals projected before visibility levels
+// existed, so they show up in the public reads. A failure leaves those
+// signals visible to admins only and is retried on the next start.
+func migrateVisibility(ctx context.Context, proj projection.SignalProjection) {
+	migrated, err := proj.MigrateVisibility(ctx)
+	if err != nil {
+		slog.Warn("visibility migration failed, unmigrated signals stay admin-only", "error", err)
+		return
+	}
+	if migrated > 0 {
+		slog.Info("visibility migration complete", "signals", migrated)
+	}
+}
+
+func startConsumer(ctx, abort context.Context, background *workers, proj projection.SignalProjection, kafkaConfig config.Kafka, cfg config.Consumer) {
+	connection := kafkaConnection(kafkaConfig)
+	positions, err := consumer.Resume(ctx, proj, connection, kafkaConfig.GroupID)
+	if err != nil {
+		slog.Warn("resuming from stored positions failed, relying on redelivery checks", "error", err)
+	} else {
+		slog.Info("resuming from stored positions", "partitions", len(positions))
+	}
+
+	startOffset := kafka.FirstOffset
+	if kafkaConfig.StartOffset == config.StartOffsetLast {
+		startOffset = kafka.LastOffset
+	}
 	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:     []string{envOrDefault("KAFKA_BROKERS", "localhost:9092")},
-		Topic:       "nexus.signals",
-		GroupID:     "nexus-data-plane",
-		StartOffset: kafka.FirstOffset,
+		Brokers:     connection.Brokers,
+		Dialer:      connection.Dialer(),
+		Topic:       kafkaConfig.Topic,
+		GroupID:     kafkaConfig.GroupID,
+		StartOffset: startOffset,
+		MaxWait:     cfg.MaxWait,
+		MaxBytes:    cfg.MaxBytes,
 	})
-	cons := consumer.New(reader, proj)
-	go func() {
-		log.Println("consumer started")
+	policy := consumer.ValidationPolicy(cfg.ValidationPolicy)
+	deadLetter := &kafka.Writer{
+		Addr:                   kafka.TCP(connection.Brokers...),
+		Transport:              connection.Transport(),
+		Topic:                  kafkaConfig.DeadLetterTopic,
+		Balancer:               &kafka.Hash{},
+		AllowAutoTopicCreation: true,
+	}
+	options := []consumer.Option{
+		consumer.WithValidationPolicy(policy),
+		consumer.WithDeadLetter(deadLetter),
+		consumer.WithRetryInterval(cfg.RetryInterval),
+	}
+	if dir := cfg.SchemaRegistryDir; dir != "" {
+		schemas, err := registry.LoadLocal(dir)
+		if err != nil {
+			fatal("loading schema registry failed", "error", err)
+		}
+		options = append(options, consumer.WithSchemas(schemas))
+		slog.Info("decoding protobuf events", "schemas", dir)
+	}
+	cons := consumer.New(reader, proj, options...)
+	background.Go("consumer", func() {
+		slog.Info("consumer started", "validation_policy", policy)
 		defer func() {
 			if err := reader.Close(); err != nil {
-				log.Printf("kafka reader close error: %v", err)
+				slog.Error("kafka reader close failed", "error", err)
+			}
+			if err := deadLetter.Close(); err != nil {
+				slog.Error("dead-letter writer close failed", "error", err)
 			}
 		}()
-		if err := cons.Start(ctx); err != nil {
-			log.Printf("consumer stopped: %v", err)
+		if err := cons.Start(ctx, abort); err != nil {
+			slog.Info("consumer stopped", "reason", err)
 		}
-	}()
+	})
+}
+
+func kafkaConnection(cfg config.Kafka) consumer.Connection {
+	tlsConfig, err := cfg.TLS.Build()
+	if err != nil {
+		fatal("invalid kafka TLS configuration", "error", err)
+	}
+	mechanism, err := consumer.NewSASLMechanism(cfg.SASL.Mechanism, cfg.SASL.Username, cfg.SASL.Password)
+	if err != nil {
+		fatal("invalid kafka SASL configuration", "error", err)
+	}
+	slog.Info("kafka connection configured", "tls", cfg.TLS.Enabled, "sasl", cfg.SASL.Mechanism)
+	return consumer.Connection{Brokers: cfg.Brokers, TLS: tlsConfig, SASL: mechanism}
+}
+
+func startSweeper(ctx context.Context, background *workers, proj projection.SignalProjection, cfg config.Retention) {
+	policy := retention.Policy{
+		MaxAge:         cfg.MaxAge,
+		MaxPerPriority: cfg.MaxPerPriority,
+	}
+	if !policy.Enabled() {
+		slog.Info("retention disabled")
+		return
+	}
+	interval := cfg.Interval
+	sweeper := retention.New(proj, policy, interval)
+	background.Go("retention sweeper", func() {
+		slog.Info("retention sweeper started",
+			"max_age", policy.MaxAge, "max_per_priority", policy.MaxPerPriority, "interval", interval)
+		if err := sweeper.Run(ctx); err != nil {
+			slog.Info("retention sweeper stopped", "reason", err)
+		}
+	})
 }
 
-func serveHTTP(ctx context.Context, proj projection.SignalProjection) {
-	signalHandler := handler.New(proj)
+// serveHTTP serves the routes of the configured role until shutdown, then
+// drains open connections until abort, reporting whether it managed to.
+// Read-serving roles stop taking
+// requests right away; a consumer-only instance keeps answering health checks
+// and metrics until its workers have finished and left the consumer group.
+func serveHTTP(ctx, abort context.Context, background *workers, redisClient *redis.Client, proj projection.SignalProjection, cfg config.Config) bool {
+	options := []handler.Option{
+		handler.WithConsistencyTimeout(cfg.HTTP.ConsistencyTimeout),
+	}
+	if cfg.ServesReads() {
+		options = append(options, authOptions(cfg.Auth)...)
+		options = append(options, rateLimitOptions(cfg.RateLimit, redisClient)...)
+	}
+	signalHandler := handler.New(proj, options...)
 	mux := http.NewServeMux()
-	signalHandler.Register(mux)
+	if cfg.ServesReads() {
+		signalHandler.Register(mux)
+	} else {
+		signalHandler.RegisterHealth(mux)
+	}
+	mux.Handle("GET /debug/vars", expvar.Handler())
 
-	addr := envOrDefault("HTTP_ADDR", ":8081")
-	server := &http.Server{Addr: addr, Handler: mux}
+	var active activeRequests
+	server := &http.Server{
+		Addr:              cfg.HTTP.Addr,
+		Handler:           handler.Instrument(mux),
+		ReadHeaderTimeout: cfg.HTTP.ReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTP.ReadTimeout,
+		WriteTimeout:      cfg.HTTP.WriteTimeout,
+		IdleTimeout:       cfg.HTTP.IdleTimeout,
+		ConnState:         active.track,
+	}
 
+	if cfg.HTTP.TLS.Enabled {
+		server.TLSConfig = serverTLS(ctx, cfg.HTTP.TLS)
+	}
+
+	failed := make(chan error, 1)
 	go func() {
-		<-ctx.Done()
-		if err := server.Shutdown(context.Background()); err != nil {
-			log.Printf("http shutdown error: %v", err)
+		if server.TLSConfig != nil {
+			failed <- server.ListenAndServeTLS("", "")
+			return
 		}
+		failed <- server.ListenAndServe()
 	}()
+	slog.Info("http server listening", "addr", cfg.HTTP.Addr, "tls", cfg.HTTP.TLS.Enabled, "client_auth", cfg.HTTP.TLS.ClientAuth)
+
+	select {
+	case err := <-failed:
+		fatal("http server failed", "error", err)
+	case <-ctx.Done():
+	}
+	if !cfg.ServesReads() {
+		background.Wait(abort)
+	}
+	if err := server.Shutdown(abort); err != nil {
+		slog.Error("http drain deadline exceeded, abandoning requests", "requests", active.count())
+		if err := server.Close(); err != nil {
+			slog.Error("http close failed", "error", err)
+		}
+		return false
+	}
+	slog.Info("http server drained")
+	return true
+}
 
-	log.Printf("http server listening on %s", addr)
-	if err := server.ListenAndServe(); err != http.ErrServerClosed {
-		log.Fatalf("http server error: %v", err)
+// authOptions builds the authenticator guarding the read routes, if any.
+func authOptions(cfg config.Auth) []handler.Option {
+	authenticator, err := cfg.Build()
+	if err != nil {
+		fatal("invalid auth configuration", "error", err)
 	}
-	log.Println("shutdown complete")
+	if authenticator == nil {
+		slog.Warn("authentication disabled, the read API is open to anyone who can reach it")
+		return nil
+	}
+	slog.Info("authentication enabled", "api_keys", len(cfg.APIKeys), "jwt", cfg.JWT.JWKSFile != "")
+	return []handler.Option{handler.WithAuthenticator(*authenticator)}
 }
 
-func envOrDefault(key, fallback string) string {
-	value := os.Getenv(key)
-	if value != "" {
-		return value
+// rateLimitOptions builds the limiter guarding the read routes, if any.
+func rateLimitOptions(cfg config.RateLimit, redisClient *redis.Client) []handler.Option {
+	limiter := cfg.Build(redisClient)
+	if limiter == nil {
+		return nil
+	}
+	slog.Info("rate limiting enabled", "requests", cfg.Requests, "period", cfg.Period, "burst", cfg.Burst, "clients", len(cfg.Clients))
+	return []handler.Option{handler.WithRateLimiter(*limiter)}
+}
+
+// serverTLS loads the server certificate, reloading it whenever its files
+// change until ctx is cancelled.
+func serverTLS(ctx context.Context, cfg config.ServerTLS) *tls.Config {
+	certificates, err := tlsconfig.NewCertReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		fatal("loading server certificate failed", "error", err)
+	}
+	tlsConfig, err := tlsconfig.Server(certificates, tlsconfig.ServerOptions{
+		ClientAuth:   cfg.ClientAuth,
+		ClientCAFile: cfg.ClientCAFile,
+	})
+	if err != nil {
+		fatal("invalid http TLS configuration", "error", err)
 	}
-	return fallback
+	go func() {
+		_ = certificates.Run(ctx, cfg.ReloadInterval)
+	}()
+	return tlsConfig
+}
+
+// fatal logs at error level and exits.
+func fatal(message string, args ...any) {
+	slog.Error(message, args...)
+	os.Exit(1)
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// workers tracks background components by name, so shutdown can wait for
+// them and report the ones it had to abandon. The zero value is ready to use.
+type workers struct {
+	group   sync.WaitGroup
+	mu      sync.Mutex
+	running map[string]struct{}
+}
+
+// Go runs fn in a goroutine tracked under name.
+func (w *workers) Go(name string, fn func()) {
+	w.mu.Lock()
+	if w.running == nil {
+		w.running = make(map[string]struct{})
+	}
+	w.running[name] = struct{}{}
+	w.mu.Unlock()
+
+	w.group.Go(func() {
+		defer func() {
+			w.mu.Lock()
+			delete(w.running, name)
+			w.mu.Unlock()
+		}()
+		fn()
+	})
+}
+
+// Wait blocks until every worker has returned or ctx is done, and returns
+// the names of the workers still running.
+func (w *workers) Wait(ctx context.Context) []string {
+	done := make(chan struct{})
+	go func() {
+		w.group.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	names := make([]string, 0, len(w.running))
+	for name := range w.running {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// activeRequests tracks the connections serving a request, to report how
+// many a forced shutdown cuts off. Its track method is an http.Server
+// ConnState hook.
+type activeRequests struct {
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+}
+
+func (a *activeRequests) track(conn net.Conn, state http.ConnState) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if state != http.StateActive {
+		delete(a.conns, conn)
+		return
+	}
+	if a.conns == nil {
+		a.conns = make(map[net.Conn]struct{})
+	}
+	a.conns[conn] = struct{}{}
+}
+
+func (a *activeRequests) count() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.conns)
+}
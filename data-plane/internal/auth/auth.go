@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ScopeReadSignals grants access to the read API.
+const ScopeReadSignals = "signals:read"
+
+// keyPrefix marks keys made by GenerateKey, so they are recognizable in
+// leaked-secret scans and never mistaken for a JWT.
+const keyPrefix = "nxs_"
+
+var (
+	// ErrMissingCredentials is returned when a request carries no token.
+	ErrMissingCredentials = errors.New("missing credentials")
+	// ErrInvalidCredentials is returned for unknown keys and for tokens that
+	// fail verification.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Subject names the caller: the API key's name or the JWT's subject.
+	Subject string
+	Scopes  []string
+	// Role is the most restricted signal visibility the caller may read;
+	// see domain.Clearance. Empty means public.
+	Role string
+}
+
+// HasScope reports whether the principal was granted scope.
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// APIKey is a static key, stored as the hex SHA-256 of its value so the
+// configuration never holds the key itself.
+type APIKey struct {
+	Name   string
+	SHA256 string
+	// Scopes granted to the key. Empty grants ScopeReadSignals.
+	Scopes []string
+	Role   string
+}
+
+// Authenticator verifies bearer tokens: API keys, and JWTs when a verifier
+// is configured.
+type Authenticator struct {
+	keys map[string]APIKey
+	jwt  *JWTVerifier
+}
+
+// New creates an Authenticator accepting keys and, when verifier is not nil,
+// the JWTs it verifies.
+func New(keys []APIKey, verifier *JWTVerifier) (Authenticator, error) {
+	authenticator := Authenticator{keys: make(map[string]APIKey, len(keys)), jwt: verifier}
+	for _, key := range keys {
+		hash := strings.ToLower(key.SHA256)
+		if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+			return Authenticator{}, fmt.Errorf("api key %q: sha256 must be 64 hex characters", key.Name)
+		}
+		if _, ok := authenticator.keys[hash]; ok {
+			return Authenticator{}, fmt.Errorf("api key %q: hash already used by another key", key.Name)
+		}
+		if len(key.Scopes) == 0 {
+			key.Scopes = []string{ScopeReadSignals}
+		}
+		authenticator.keys[hash] = key
+	}
+	return authenticator, nil
+}
+
+// Authenticate returns the caller a bearer token belongs to. Tokens shaped
+// like a JWT are verified as one; anything else is looked up as an API key.
+func (a Authenticator) Authenticate(token string) (Principal, error) {
+	if token == "" {
+		return Principal{}, ErrMissingCredentials
+	}
+	if strings.Count(token, ".") == 2 {
+		if a.jwt == nil {
+			return Principal{}, fmt.Errorf("%w: JWTs are not accepted", ErrInvalidCredentials)
+		}
+		return a.jwt.Verify(token)
+	}
+	// Keys are looked up by hash, so lookup timing reveals nothing about
+	// the stored values.
+	key, ok := a.keys[HashKey(token)]
+	if !ok {
+		return Principal{}, fmt.Errorf("%w: unknown api key", ErrInvalidCredentials)
+	}
+	return Principal{Subject: key.Name, Scopes: key.Scopes, Role: key.Role}, nil
+}
+
+// HashKey returns the hex SHA-256 of key, the form APIKey.SHA256 expects.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateKey returns a new random API key.
+func GenerateKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
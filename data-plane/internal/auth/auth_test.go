@@ -0,0 +1,97 @@
+package auth_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
+)
+
+func TestAuthenticate_APIKey(t *testing.T) {
+	key, err := auth.GenerateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authenticator, err := auth.New([]auth.APIKey{{Name: "dashboard", SHA256: auth.HashKey(key)}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	principal, err := authenticator.Authenticate(key)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if principal.Subject != "dashboard" {
+		t.Errorf("expected subject %q, got %q", "dashboard", principal.Subject)
+	}
+	if !principal.HasScope(auth.ScopeReadSignals) {
+		t.Errorf("expected keys without scopes to be granted %q, got %v", auth.ScopeReadSignals, principal.Scopes)
+	}
+}
+
+func TestAuthenticate_Rejects(t *testing.T) {
+	authenticator, err := auth.New([]auth.APIKey{{Name: "dashboard", SHA256: auth.HashKey("nxs_known")}}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := map[string]struct {
+		token string
+		want  error
+	}{
+		"no token":           {token: "", want: auth.ErrMissingCredentials},
+		"unknown key":        {token: "nxs_unknown", want: auth.ErrInvalidCredentials},
+		"hash instead":       {token: auth.HashKey("nxs_known"), want: auth.ErrInvalidCredentials},
+		"JWT without JWKS":   {token: "header.payload.signature", want: auth.ErrInvalidCredentials},
+		"case changed key":   {token: "NXS_KNOWN", want: auth.ErrInvalidCredentials},
+		"whitespace padding": {token: " nxs_known", want: auth.ErrInvalidCredentials},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := authenticator.Authenticate(test.token)
+
+			if !errors.Is(err, test.want) {
+				t.Errorf("expected %v, got %v", test.want, err)
+			}
+		})
+	}
+}
+
+func TestNew_InvalidKeys(t *testing.T) {
+	hash := auth.HashKey("nxs_known")
+	tests := map[string][]auth.APIKey{
+		"not hex":        {{Name: "a", SHA256: strings.Repeat("z", 64)}},
+		"wrong length":   {{Name: "a", SHA256: hash[:32]}},
+		"duplicate hash": {{Name: "a", SHA256: hash}, {Name: "b", SHA256: strings.ToUpper(hash)}},
+	}
+
+	for name, keys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := auth.New(keys, nil)
+
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestGenerateKey_Unique(t *testing.T) {
+	first, err := auth.GenerateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := auth.GenerateKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first == second {
+		t.Error("expected two generated keys to differ")
+	}
+	if strings.Contains(first, ".") {
+		t.Errorf("expected a key that cannot be mistaken for a JWT, got %q", first)
+	}
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Signing algorithms accepted for JWTs.
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// minRSABits rejects RSA keys too short to be trusted.
+const minRSABits = 2048
+
+// JWTOptions configures JWT verification.
+type JWTOptions struct {
+	// JWKSFile is a local JSON Web Key Set holding the verification keys:
+	// "oct" keys for HS256 and "RSA" keys for RS256.
+	JWKSFile string
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// Leeway tolerates clock skew when checking exp and nbf.
+	Leeway time.Duration
+}
+
+// JWTVerifier verifies HS256 and RS256 JWTs against a key set.
+type JWTVerifier struct {
+	keys   []verificationKey
+	parser *jwt.Parser
+}
+
+// verificationKey is a JWK decoded into the key type its algorithm uses.
+type verificationKey struct {
+	id        string
+	algorithm string
+	key       any
+}
+
+// jwtClaims are the claims read from a token. Scopes come from the
+// space-separated scope claim (RFC 8693).
+type jwtClaims struct {
+	jwt.RegisteredClaims
+	Scope string `json:"scope"`
+	Role  string `json:"role"`
+}
+
+// NewJWTVerifier loads the key set and builds a verifier. Tokens must carry
+// an exp claim.
+func NewJWTVerifier(options JWTOptions) (*JWTVerifier, error) {
+	keys, err := loadJWKS(options.JWKSFile)
+	if err != nil {
+		return nil, err
+	}
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{AlgorithmHS256, AlgorithmRS256}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(options.Leeway),
+	}
+	if options.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(options.Issuer))
+	}
+	if options.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(options.Audience))
+	}
+	return &JWTVerifier{keys: keys, parser: jwt.NewParser(parserOptions...)}, nil
+}
+
+// Verify checks the token's signature and claims and returns its subject,
+// scopes and role.
+func (v *JWTVerifier) Verify(token string) (Principal, error) {
+	var claims jwtClaims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.keyFor); err != nil {
+		return Principal{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+	if claims.Subject == "" {
+		return Principal{}, fmt.Errorf("%w: token has no subject", ErrInvalidCredentials)
+	}
+	return Principal{Subject: claims.Subject, Scopes: strings.Fields(claims.Scope), Role: claims.Role}, nil
+}
+
+// keyFor picks the key named by the token's kid header, or the only key of
+// the token's algorithm when the token names none. Keys are bound to one
+// algorithm, so an RSA public key is never used as an HMAC secret.
+func (v *JWTVerifier) keyFor(token *jwt.Token) (any, error) {
+	algorithm := token.Method.Alg()
+	id, _ := token.Header["kid"].(string)
+	var candidates []verificationKey
+	for _, key := range v.keys {
+		if key.algorithm == algorithm && (id == "" || key.id == id) {
+			candidates = append(candidates, key)
+		}
+	}
+	switch {
+	case len(candidates) == 0:
+		return nil, fmt.Errorf("no %s key with id %q", algorithm, id)
+	case len(candidates) > 1:
+		return nil, fmt.Errorf("%d %s keys match key id %q", len(candidates), algorithm, id)
+	}
+	return candidates[0].key, nil
+}
+
+// jsonWebKey holds the JWK members used for HS256 and RS256 keys.
+type jsonWebKey struct {
+	Type      string `json:"kty"`
+	ID        string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	K         string `json:"k"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+func loadJWKS(path string) ([]verificationKey, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading JWKS: %w", err)
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("parsing JWKS %s: %w", path, err)
+	}
+	var keys []verificationKey
+	for i, webKey := range set.Keys {
+		if webKey.Use != "" && webKey.Use != "sig" {
+			continue
+		}
+		key, err := decodeKey(webKey)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS %s: key %d (%q): %w", path, i, webKey.ID, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS %s: no signing keys", path)
+	}
+	return keys, nil
+}
+
+func decodeKey(webKey jsonWebKey) (verificationKey, error) {
+	key := verificationKey{id: webKey.ID}
+	switch webKey.Type {
+	case "oct":
+		secret, err := base64.RawURLEncoding.DecodeString(webKey.K)
+		if err != nil || len(secret) == 0 {
+			return key, errors.New("k must be a non-empty base64url secret")
+		}
+		key.algorithm, key.key = AlgorithmHS256, secret
+	case "RSA":
+		modulus, err := decodeInteger(webKey.N)
+		if err != nil {
+			return key, fmt.Errorf("n: %w", err)
+		}
+		if modulus.BitLen() < minRSABits {
+			return key, fmt.Errorf("RSA keys must have at least %d bits", minRSABits)
+		}
+		exponent, err := decodeInteger(webKey.E)
+		if err != nil || !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return key, errors.New("e must be a small base64url integer")
+		}
+		key.algorithm, key.key = AlgorithmRS256, &rsa.PublicKey{N: modulus, E: int(exponent.Int64())}
+	default:
+		return key, fmt.Errorf("unsupported key type %q (want \"oct\" or \"RSA\")", webKey.Type)
+	}
+	if webKey.Algorithm != "" && webKey.Algorithm != key.algorithm {
+		return key, fmt.Errorf("%s key cannot be used for %s", webKey.Type, webKey.Algorithm)
+	}
+	return key, nil
+}
+
+func decodeInteger(value string) (*big.Int, error) {
+	bytes, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(bytes) == 0 {
+		return nil, errors.New("must be a non-empty base64url integer")
+	}
+	return new(big.Int).SetBytes(bytes), nil
+}
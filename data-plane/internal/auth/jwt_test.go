@@ -0,0 +1,223 @@
+package auth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// writeJWKS writes a key set with an HS256 secret ("shared") and the public
+// half of rsaKey ("signing"), and returns its path.
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey) string {
+	t.Helper()
+	encode := base64.RawURLEncoding.EncodeToString
+	set := map[string]any{"keys": []map[string]string{
+		{"kty": "oct", "kid": "shared", "alg": "HS256", "k": encode(hmacSecret)},
+		{
+			"kty": "RSA", "kid": "signing", "alg": "RS256", "use": "sig",
+			"n": encode(rsaKey.N.Bytes()),
+			"e": encode(big.NewInt(int64(rsaKey.E)).Bytes()),
+		},
+	}}
+	return writeJSON(t, set)
+}
+
+func writeJSON(t *testing.T, value any) string {
+	t.Helper()
+	content, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("failed to encode: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, keyID string, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   "reporting",
+		"iss":   "https://auth.nexus.local",
+		"aud":   "nexus-data-plane",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"scope": "signals:read profile",
+	}
+}
+
+func newVerifier(t *testing.T, jwksFile string) auth.Authenticator {
+	t.Helper()
+	verifier, err := auth.NewJWTVerifier(auth.JWTOptions{
+		JWKSFile: jwksFile,
+		Issuer:   "https://auth.nexus.local",
+		Audience: "nexus-data-plane",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authenticator, err := auth.New(nil, verifier)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return authenticator
+}
+
+func TestJWT_AcceptsSignedTokens(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+	authenticator := newVerifier(t, writeJWKS(t, rsaKey))
+	tests := map[string]string{
+		"HS256":           sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, validClaims()),
+		"RS256":           sign(t, jwt.SigningMethodRS256, "signing", rsaKey, validClaims()),
+		"RS256 no key id": sign(t, jwt.SigningMethodRS256, "", rsaKey, validClaims()),
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			principal, err := authenticator.Authenticate(token)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if principal.Subject != "reporting" {
+				t.Errorf("expected subject %q, got %q", "reporting", principal.Subject)
+			}
+			if !principal.HasScope(auth.ScopeReadSignals) {
+				t.Errorf("expected scope %q, got %v", auth.ScopeReadSignals, principal.Scopes)
+			}
+		})
+	}
+}
+
+func TestJWT_RejectsInvalidTokens(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+	authenticator := newVerifier(t, writeJWKS(t, rsaKey))
+	with := func(key string, value any) jwt.MapClaims {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, key)
+		} else {
+			claims[key] = value
+		}
+		return claims
+	}
+	publicKeyAsSecret := rsaKey.PublicKey.N.Bytes()
+	tests := map[string]string{
+		"expired":          sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, with("exp", time.Now().Add(-time.Minute).Unix())),
+		"no expiry":        sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, with("exp", nil)),
+		"not yet valid":    sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, with("nbf", time.Now().Add(time.Hour).Unix())),
+		"wrong issuer":     sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, with("iss", "https://elsewhere")),
+		"wrong audience":   sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, with("aud", "billing")),
+		"no subject":       sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, with("sub", nil)),
+		"wrong secret":     sign(t, jwt.SigningMethodHS256, "shared", []byte("another secret, same length...."), validClaims()),
+		"unknown key id":   sign(t, jwt.SigningMethodRS256, "rotated", rsaKey, validClaims()),
+		"algorithm swap":   sign(t, jwt.SigningMethodHS256, "signing", publicKeyAsSecret, validClaims()),
+		"unsigned":         sign(t, jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, validClaims()),
+		"other algorithm":  sign(t, jwt.SigningMethodHS512, "shared", hmacSecret, validClaims()),
+		"malformed":        "not.a.token",
+		"tampered payload": tamper(t, sign(t, jwt.SigningMethodRS256, "signing", rsaKey, validClaims())),
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := authenticator.Authenticate(token)
+
+			if !errors.Is(err, auth.ErrInvalidCredentials) {
+				t.Errorf("expected %v, got %v", auth.ErrInvalidCredentials, err)
+			}
+		})
+	}
+}
+
+// tamper replaces the token's subject while keeping its signature.
+func tamper(t *testing.T, token string) string {
+	t.Helper()
+	claims := validClaims()
+	claims["sub"] = "admin"
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("failed to encode claims: %v", err)
+	}
+	parts := strings.Split(token, ".")
+	parts[1] = base64.RawURLEncoding.EncodeToString(payload)
+	return strings.Join(parts, ".")
+}
+
+func TestNewJWTVerifier_InvalidKeySets(t *testing.T) {
+	encode := base64.RawURLEncoding.EncodeToString
+	shortKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	tests := map[string]any{
+		"not JSON":         "keys",
+		"no keys":          map[string]any{"keys": []any{}},
+		"encryption only":  map[string]any{"keys": []any{map[string]string{"kty": "oct", "use": "enc", "k": encode(hmacSecret)}}},
+		"unsupported type": map[string]any{"keys": []any{map[string]string{"kty": "EC", "crv": "P-256"}}},
+		"empty secret":     map[string]any{"keys": []any{map[string]string{"kty": "oct"}}},
+		"mismatched alg":   map[string]any{"keys": []any{map[string]string{"kty": "oct", "alg": "RS256", "k": encode(hmacSecret)}}},
+		"short RSA key": map[string]any{"keys": []any{map[string]string{
+			"kty": "RSA", "n": encode(shortKey.N.Bytes()), "e": encode(big.NewInt(int64(shortKey.E)).Bytes()),
+		}}},
+	}
+
+	for name, set := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := auth.NewJWTVerifier(auth.JWTOptions{JWKSFile: writeJSON(t, set)})
+
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestJWT_RoleClaim(t *testing.T) {
+	authenticator := newVerifier(t, writeJWKS(t, generateRSAKey(t)))
+	claims := validClaims()
+	claims["role"] = "internal"
+	token := sign(t, jwt.SigningMethodHS256, "shared", hmacSecret, claims)
+
+	principal, err := authenticator.Authenticate(token)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if principal.Role != "internal" {
+		t.Errorf("expected role %q, got %q", "internal", principal.Role)
+	}
+}
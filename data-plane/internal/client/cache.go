@@ -0,0 +1,56 @@
+package client
+
+import "sync"
+
+// defaultCacheSize is how many responses a client keeps for revalidation
+// unless WithCacheSize says otherwise.
+const defaultCacheSize = 64
+
+// cachedResponse is a response body and the ETag it was served with.
+type cachedResponse struct {
+	etag string
+	body []byte
+}
+
+// responseCache keeps the latest ETag-validated response per path, evicting
+// the oldest path once full. A nil cache stores nothing. It is shared by the
+// copies of a client, so it is safe for concurrent use.
+type responseCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[string]cachedResponse
+	order   []string
+}
+
+func newResponseCache(size int) *responseCache {
+	if size <= 0 {
+		return nil
+	}
+	return &responseCache{size: size, entries: make(map[string]cachedResponse, size)}
+}
+
+func (c *responseCache) get(path string) (cachedResponse, bool) {
+	if c == nil {
+		return cachedResponse{}, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, ok := c.entries[path]
+	return entry, ok
+}
+
+func (c *responseCache) put(path string, entry cachedResponse) {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.entries[path]; !ok {
+		if len(c.order) == c.size {
+			delete(c.entries, c.order[0])
+			c.order = c.order[1:]
+		}
+		c.order = append(c.order, path)
+	}
+	c.entries[path] = entry
+}
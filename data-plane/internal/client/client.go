@@ -1,10 +1,17 @@
 package client
 
 import (
+	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
@@ -13,36 +20,188 @@ import (
 // ErrNotFound is returned when the requested signal does not exist.
 var ErrNotFound = errors.New("signal not found")
 
+// ErrNotConsistent is returned when the projection did not catch up with the
+// client's consistency token in time. The request can be retried.
+var ErrNotConsistent = errors.New("projection has not caught up with the consistency token")
+
+// ErrUnauthorized is returned when the API requires credentials and the
+// client's token is missing, unknown or expired.
+var ErrUnauthorized = errors.New("missing or invalid API token")
+
+// ErrForbidden is returned when the client's token is valid but does not
+// grant access to the resource.
+var ErrForbidden = errors.New("API token not allowed to read signals")
+
+// ErrRateLimited is returned when the API keeps refusing the client's
+// requests for exceeding its rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+const consistencyHeader = "X-Consistency-Token"
+
+// apiPrefix is the path prefix of the read API version the client speaks.
+const apiPrefix = "/" + domain.APIVersion
+
+const (
+	// maxBatchIDs mirrors the API's cap on IDs per batch get.
+	maxBatchIDs = 500
+	// maxBatchQuery is the longest ID list GetSignals sends in a query
+	// string before switching to POST.
+	maxBatchQuery = 1024
+)
+
+const (
+	defaultRateLimitRetries = 3
+	// defaultRetryAfter is how long to wait on a 429 without a usable
+	// Retry-After header.
+	defaultRetryAfter = time.Second
+	// maxRetryAfter caps the wait on a 429: asked to wait longer, the client
+	// gives up with ErrRateLimited instead.
+	maxRetryAfter = 30 * time.Second
+)
+
 // DataPlane is an HTTP client for the data-plane read API.
 type DataPlane struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL          string
+	httpClient       *http.Client
+	consistencyToken string
+	fields           []string
+	token            string
+	rateLimitRetries int
+	cache            *responseCache
+}
+
+// Option configures a DataPlane client.
+type Option func(*DataPlane)
+
+// WithTLSConfig sets the TLS configuration of HTTPS connections, e.g. to
+// trust a private CA or present a client certificate. See tlsconfig.Client.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(d *DataPlane) {
+		transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if defaults, ok := http.DefaultTransport.(*http.Transport); ok {
+			transport = defaults.Clone()
+		}
+		transport.TLSClientConfig = config
+		d.httpClient.Transport = transport
+	}
+}
+
+// WithToken authenticates requests with an API key or a JWT, sent as a
+// bearer token.
+func WithToken(token string) Option {
+	return func(d *DataPlane) {
+		d.token = token
+	}
+}
+
+// WithRateLimitRetries sets how many times a request refused with 429 is
+// retried, each after waiting as long as the response's Retry-After asks.
+// Zero disables retries.
+func WithRateLimitRetries(retries int) Option {
+	return func(d *DataPlane) {
+		d.rateLimitRetries = retries
+	}
+}
+
+// WithCacheSize sets how many responses the client keeps to revalidate with
+// If-None-Match, answering from the cache when the API reports them
+// unchanged. Zero disables the cache.
+func WithCacheSize(size int) Option {
+	return func(d *DataPlane) {
+		d.cache = newResponseCache(size)
+	}
 }
 
 // New creates a DataPlane client targeting the given base URL.
-func New(baseURL string) DataPlane {
-	return DataPlane{
-		baseURL:    baseURL,
-		httpClient: &http.Client{Timeout: 5 * time.Second},
+func New(baseURL string, options ...Option) DataPlane {
+	dataPlane := DataPlane{
+		baseURL:          baseURL,
+		httpClient:       &http.Client{Timeout: 5 * time.Second},
+		rateLimitRetries: defaultRateLimitRetries,
+		cache:            newResponseCache(defaultCacheSize),
+	}
+	for _, option := range options {
+		option(&dataPlane)
 	}
+	return dataPlane
+}
+
+// WithConsistencyToken returns a copy of the client whose reads wait until
+// the projection reflects token: a "<partition>:<offset>" pair, or a
+// signal's "<id>@<updated_at>" after a write or "<id>@deleted" after a
+// delete.
+func (d DataPlane) WithConsistencyToken(token string) DataPlane {
+	d.consistencyToken = token
+	return d
+}
+
+// WithFields returns a copy of the client whose signal reads ask the API for
+// just the given fields, e.g. "id", "title" and "priority". The other fields
+// of the signals returned are left empty. No fields reads them all.
+func (d DataPlane) WithFields(fields ...string) DataPlane {
+	d.fields = slices.Clone(fields)
+	return d
 }
 
 // ListSignals returns all signals, optionally filtered by priority.
 func (d DataPlane) ListSignals(priority string) ([]domain.Signal, error) {
-	path := "/signals"
+	path := apiPrefix + "/signals"
 	if priority != "" {
 		path = path + "?priority=" + priority
 	}
-	var signals []domain.Signal
-	err := d.fetchJSON(path, &signals)
-	return signals, err
+	var body domain.Envelope[[]domain.Signal]
+	err := d.fetchJSON(d.selecting(path), &body)
+	return body.Data, err
 }
 
 // GetSignal returns a single signal by its ID.
 func (d DataPlane) GetSignal(id string) (domain.Signal, error) {
-	var signal domain.Signal
-	err := d.fetchJSON("/signals/"+id, &signal)
-	return signal, err
+	var body domain.Envelope[domain.Signal]
+	err := d.fetchJSON(d.selecting(apiPrefix+"/signals/"+id), &body)
+	return body.Data, err
+}
+
+// GetSignals returns one item per id, in the order given: the signal, or a
+// not-found marker for IDs that do not exist or are hidden from the client.
+// Short lists are sent as a cacheable GET, long ones as a POST, split into
+// as many requests as the API's per-request cap needs.
+func (d DataPlane) GetSignals(ids []string) ([]domain.BatchItem, error) {
+	items := make([]domain.BatchItem, 0, len(ids))
+	for start := 0; start < len(ids); start += maxBatchIDs {
+		chunk := ids[start:min(start+maxBatchIDs, len(ids))]
+		var batch domain.Envelope[[]domain.BatchItem]
+		var err error
+		joined := strings.Join(chunk, ",")
+		if len(joined) <= maxBatchQuery && !slices.ContainsFunc(chunk, func(id string) bool { return strings.Contains(id, ",") }) {
+			err = d.fetchJSON(d.selecting(apiPrefix+"/signals?ids="+url.QueryEscape(joined)), &batch)
+		} else {
+			err = d.postJSON(d.selecting(apiPrefix+"/signals/batch"), map[string][]string{"ids": chunk}, &batch)
+		}
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, batch.Data...)
+	}
+	return items, nil
+}
+
+// Stats returns signal counts, optionally bounded by creation day
+// (YYYY-MM-DD, inclusive). Empty bounds are unbounded.
+func (d DataPlane) Stats(from, to string) (domain.Stats, error) {
+	query := url.Values{}
+	if from != "" {
+		query.Set("from", from)
+	}
+	if to != "" {
+		query.Set("to", to)
+	}
+	path := apiPrefix + "/stats"
+	if len(query) > 0 {
+		path = path + "?" + query.Encode()
+	}
+	var body domain.Envelope[domain.Stats]
+	err := d.fetchJSON(path, &body)
+	return body.Data, err
 }
 
 // Health checks the data-plane's health endpoint.
@@ -51,23 +210,122 @@ func (d DataPlane) Health() error {
 	return d.fetchJSON("/health", &result)
 }
 
+// selecting adds the client's fields, if any, to the query of path.
+func (d DataPlane) selecting(path string) string {
+	if len(d.fields) == 0 {
+		return path
+	}
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "fields=" + url.QueryEscape(strings.Join(d.fields, ","))
+}
+
+// fetchJSON decodes the response to a GET of path into target, revalidating
+// a cached copy when there is one.
 func (d DataPlane) fetchJSON(path string, target interface{}) error {
-	response, err := d.get(path)
+	cached, found := d.cache.get(path)
+	response, err := d.do(http.MethodGet, path, nil, cached.etag)
 	if err != nil {
 		return fmt.Errorf("connection failed: %w", err)
 	}
 	defer func() { _ = response.Body.Close() }()
+	if d.consistencyToken != "" && response.StatusCode == http.StatusServiceUnavailable {
+		return ErrNotConsistent
+	}
+	if found && response.StatusCode == http.StatusNotModified {
+		return json.Unmarshal(cached.body, target)
+	}
+	etag := response.Header.Get("ETag")
+	if d.cache == nil || etag == "" || response.StatusCode != http.StatusOK {
+		return decodeResponse(response, target)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("reading response failed: %w", err)
+	}
+	d.cache.put(path, cachedResponse{etag: etag, body: body})
+	return json.Unmarshal(body, target)
+}
+
+// postJSON posts payload as JSON to path and decodes the response into
+// target.
+func (d DataPlane) postJSON(path string, payload, target interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	response, err := d.do(http.MethodPost, path, body, "")
+	if err != nil {
+		return fmt.Errorf("connection failed: %w", err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	if d.consistencyToken != "" && response.StatusCode == http.StatusServiceUnavailable {
+		return ErrNotConsistent
+	}
 	return decodeResponse(response, target)
 }
 
-func (d DataPlane) get(path string) (*http.Response, error) {
-	url := d.baseURL + path
-	return d.httpClient.Get(url)
+// do sends a request for path, conditional on etag when it is set, waiting
+// out 429 responses as long as their Retry-After asks, up to
+// d.rateLimitRetries times.
+func (d DataPlane) do(method, path string, body []byte, etag string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		response, err := d.send(method, path, body, etag)
+		if err != nil || response.StatusCode != http.StatusTooManyRequests || attempt == d.rateLimitRetries {
+			return response, err
+		}
+		wait := retryAfter(response.Header.Get("Retry-After"))
+		if wait > maxRetryAfter {
+			return response, nil
+		}
+		_ = response.Body.Close()
+		time.Sleep(wait)
+	}
+}
+
+func (d DataPlane) send(method, path string, body []byte, etag string) (*http.Response, error) {
+	request, err := http.NewRequest(method, d.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if d.consistencyToken != "" {
+		request.Header.Set(consistencyHeader, d.consistencyToken)
+	}
+	if d.token != "" {
+		request.Header.Set("Authorization", "Bearer "+d.token)
+	}
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+	return d.httpClient.Do(request)
+}
+
+// retryAfter parses a Retry-After header, in seconds or as an HTTP date.
+func retryAfter(value string) time.Duration {
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+	return defaultRetryAfter
 }
 
 func decodeResponse(response *http.Response, target interface{}) error {
-	if response.StatusCode == http.StatusNotFound {
+	switch response.StatusCode {
+	case http.StatusNotFound:
 		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
 	}
 	if response.StatusCode != http.StatusOK {
 		return fmt.Errorf("unexpected status: %d", response.StatusCode)
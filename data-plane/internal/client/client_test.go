@@ -1,10 +1,14 @@
 package client_test
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/client"
@@ -26,13 +30,19 @@ func respondJSON(t *testing.T, writer http.ResponseWriter, status int, body inte
 	}
 }
 
+// respondData answers 200 with data in the v1 envelope.
+func respondData(t *testing.T, writer http.ResponseWriter, data interface{}) {
+	t.Helper()
+	respondJSON(t, writer, http.StatusOK, domain.Envelope[interface{}]{Data: data, Meta: domain.Meta{Version: domain.APIVersion}})
+}
+
 func TestListSignals_ReturnsSignals(t *testing.T) {
 	signals := []domain.Signal{
 		{ID: "s1", Title: "Alert", Priority: "High", Author: "otavio"},
 		{ID: "s2", Title: "Info", Priority: "Low", Author: "otavio"},
 	}
 	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
-		respondJSON(t, writer, http.StatusOK, signals)
+		respondData(t, writer, signals)
 	})
 	defer server.Close()
 
@@ -51,12 +61,15 @@ func TestListSignals_ReturnsSignals(t *testing.T) {
 
 func TestListSignals_SendsPriorityQuery(t *testing.T) {
 	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/v1/signals" {
+			t.Errorf("expected path %q, got %q", "/v1/signals", request.URL.Path)
+		}
 		query := request.URL.Query()
 		priority := query.Get("priority")
 		if priority != "High" {
 			t.Errorf("expected priority query %q, got %q", "High", priority)
 		}
-		respondJSON(t, writer, http.StatusOK, []domain.Signal{})
+		respondData(t, writer, []domain.Signal{})
 	})
 	defer server.Close()
 
@@ -65,7 +78,7 @@ func TestListSignals_SendsPriorityQuery(t *testing.T) {
 
 func TestListSignals_EmptyList(t *testing.T) {
 	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
-		respondJSON(t, writer, http.StatusOK, []domain.Signal{})
+		respondData(t, writer, []domain.Signal{})
 	})
 	defer server.Close()
 
@@ -82,7 +95,7 @@ func TestListSignals_EmptyList(t *testing.T) {
 func TestGetSignal_Found(t *testing.T) {
 	expected := domain.Signal{ID: "abc-123", Title: "Alert", Priority: "High"}
 	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
-		respondJSON(t, writer, http.StatusOK, expected)
+		respondData(t, writer, expected)
 	})
 	defer server.Close()
 
@@ -163,13 +176,352 @@ func TestConnectionRefused(t *testing.T) {
 
 func TestGetSignal_RequestsCorrectPath(t *testing.T) {
 	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
-		expectedPath := "/signals/uuid-456"
+		expectedPath := "/v1/signals/uuid-456"
 		if request.URL.Path != expectedPath {
 			t.Errorf("expected path %q, got %q", expectedPath, request.URL.Path)
 		}
-		respondJSON(t, writer, http.StatusOK, domain.Signal{ID: "uuid-456"})
+		respondData(t, writer, domain.Signal{ID: "uuid-456"})
 	})
 	defer server.Close()
 
 	_, _ = dataPlane.GetSignal("uuid-456")
 }
+
+func TestStats_SendsDateRange(t *testing.T) {
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		query := request.URL.Query()
+		if request.URL.Path != "/v1/stats" {
+			t.Errorf("expected path %q, got %q", "/v1/stats", request.URL.Path)
+		}
+		if query.Get("from") != "2026-02-01" || query.Get("to") != "2026-02-28" {
+			t.Errorf("unexpected date range: %v", query)
+		}
+		respondData(t, writer, domain.Stats{Total: 3, ByPriority: map[string]int64{"High": 3}})
+	})
+	defer server.Close()
+
+	stats, err := dataPlane.Stats("2026-02-01", "2026-02-28")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 3 {
+		t.Errorf("expected total 3, got %d", stats.Total)
+	}
+	if stats.ByPriority["High"] != 3 {
+		t.Errorf("expected 3 High signals, got %v", stats.ByPriority)
+	}
+}
+
+func TestWithConsistencyToken_SendsHeader(t *testing.T) {
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		token := request.Header.Get("X-Consistency-Token")
+		if token != "0:42" {
+			t.Errorf("expected consistency token %q, got %q", "0:42", token)
+		}
+		respondData(t, writer, domain.Signal{ID: "abc-123"})
+	})
+	defer server.Close()
+
+	_, err := dataPlane.WithConsistencyToken("0:42").GetSignal("abc-123")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestWithConsistencyToken_NotCaughtUp(t *testing.T) {
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Retry-After", "1")
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer server.Close()
+
+	_, err := dataPlane.WithConsistencyToken("abc-123@2026-02-23T15:05:00Z").ListSignals("")
+
+	if !errors.Is(err, client.ErrNotConsistent) {
+		t.Errorf("expected ErrNotConsistent, got %v", err)
+	}
+}
+
+func TestWithConsistencyToken_LeavesOriginalUntouched(t *testing.T) {
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		if token := request.Header.Get("X-Consistency-Token"); token != "" {
+			t.Errorf("expected no consistency token, got %q", token)
+		}
+		respondData(t, writer, []domain.Signal{})
+	})
+	defer server.Close()
+
+	_ = dataPlane.WithConsistencyToken("0:42")
+	_, _ = dataPlane.ListSignals("")
+}
+
+func TestWithTLSConfig_TrustsServer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		respondJSON(t, writer, http.StatusOK, map[string]string{"status": "ok"})
+	}))
+	defer server.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	untrusted := client.New(server.URL)
+	trusted := client.New(server.URL, client.WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}))
+
+	if err := untrusted.Health(); err == nil {
+		t.Error("expected an untrusted certificate to be refused")
+	}
+	if err := trusted.Health(); err != nil {
+		t.Errorf("expected the CA bundle to be trusted, got %v", err)
+	}
+}
+
+func TestWithToken_SendsBearerToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		authorization := request.Header.Get("Authorization")
+		if authorization != "Bearer nxs_secret" {
+			t.Errorf("expected bearer token, got %q", authorization)
+		}
+		respondData(t, writer, []domain.Signal{})
+	}))
+	defer server.Close()
+	dataPlane := client.New(server.URL, client.WithToken("nxs_secret"))
+
+	_, err := dataPlane.ListSignals("")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAuthenticationErrors(t *testing.T) {
+	tests := map[int]error{
+		http.StatusUnauthorized: client.ErrUnauthorized,
+		http.StatusForbidden:    client.ErrForbidden,
+	}
+
+	for status, want := range tests {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+				respondJSON(t, writer, status, map[string]string{"error": "denied"})
+			})
+			defer server.Close()
+
+			_, err := dataPlane.GetSignal("abc-123")
+
+			if !errors.Is(err, want) {
+				t.Errorf("expected %v, got %v", want, err)
+			}
+		})
+	}
+}
+
+func TestRateLimited_RetriesAfterWaiting(t *testing.T) {
+	attempts := 0
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		attempts++
+		if attempts == 1 {
+			writer.Header().Set("Retry-After", "0")
+			respondJSON(t, writer, http.StatusTooManyRequests, map[string]string{"error": "rate limit exceeded"})
+			return
+		}
+		respondData(t, writer, domain.Signal{ID: "abc-123"})
+	})
+	defer server.Close()
+
+	signal, err := dataPlane.GetSignal("abc-123")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signal.ID != "abc-123" || attempts != 2 {
+		t.Errorf("expected the signal after 2 attempts, got %q after %d", signal.ID, attempts)
+	}
+}
+
+func TestRateLimited_GivesUp(t *testing.T) {
+	tests := map[string]struct {
+		retryAfter string
+		retries    int
+		attempts   int
+	}{
+		"retries exhausted":   {retryAfter: "0", retries: 2, attempts: 3},
+		"retries disabled":    {retryAfter: "0", retries: 0, attempts: 1},
+		"wait beyond the cap": {retryAfter: "3600", retries: 2, attempts: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				attempts++
+				writer.Header().Set("Retry-After", test.retryAfter)
+				respondJSON(t, writer, http.StatusTooManyRequests, map[string]string{"error": "rate limit exceeded"})
+			}))
+			defer server.Close()
+			dataPlane := client.New(server.URL, client.WithRateLimitRetries(test.retries))
+
+			_, err := dataPlane.ListSignals("")
+
+			if !errors.Is(err, client.ErrRateLimited) {
+				t.Errorf("expected %v, got %v", client.ErrRateLimited, err)
+			}
+			if attempts != test.attempts {
+				t.Errorf("expected %d attempts, got %d", test.attempts, attempts)
+			}
+		})
+	}
+}
+
+func TestCache_RevalidatesWithETag(t *testing.T) {
+	var conditions []string
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		conditions = append(conditions, request.Header.Get("If-None-Match"))
+		writer.Header().Set("ETag", `"v1"`)
+		if request.Header.Get("If-None-Match") == `"v1"` {
+			writer.WriteHeader(http.StatusNotModified)
+			return
+		}
+		respondData(t, writer, domain.Signal{ID: "abc-123", Title: "Alert"})
+	})
+	defer server.Close()
+
+	first, err := dataPlane.GetSignal("abc-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := dataPlane.GetSignal("abc-123")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second != first {
+		t.Errorf("expected the cached signal %+v, got %+v", first, second)
+	}
+	if len(conditions) != 2 || conditions[0] != "" || conditions[1] != `"v1"` {
+		t.Errorf("expected an unconditional then a conditional request, got %q", conditions)
+	}
+}
+
+func TestCache_Evicts(t *testing.T) {
+	tests := map[string]struct {
+		size        int
+		conditional bool
+	}{
+		"disabled":     {size: 0, conditional: false},
+		"evicted":      {size: 1, conditional: false},
+		"large enough": {size: 2, conditional: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var condition string
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				condition = request.Header.Get("If-None-Match")
+				writer.Header().Set("ETag", `"`+request.URL.Path+`"`)
+				respondData(t, writer, domain.Signal{ID: "abc-123"})
+			}))
+			defer server.Close()
+			dataPlane := client.New(server.URL, client.WithCacheSize(test.size))
+
+			for _, id := range []string{"first", "second", "first"} {
+				if _, err := dataPlane.GetSignal(id); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			if (condition != "") != test.conditional {
+				t.Errorf("expected conditional %v, got If-None-Match %q", test.conditional, condition)
+			}
+		})
+	}
+}
+
+func TestGetSignals_QueryForShortLists(t *testing.T) {
+	var query string
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		query = request.URL.Query().Get("ids")
+		respondData(t, writer, []domain.BatchItem{
+			{ID: "s1", Found: true, Signal: &domain.Signal{ID: "s1"}},
+			{ID: "missing"},
+		})
+	})
+	defer server.Close()
+
+	items, err := dataPlane.GetSignals([]string{"s1", "missing"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if query != "s1,missing" {
+		t.Errorf("expected ids %q, got %q", "s1,missing", query)
+	}
+	if len(items) != 2 || !items[0].Found || items[1].Found {
+		t.Errorf("expected a found and a missing item, got %+v", items)
+	}
+}
+
+func TestGetSignals_PostsLongLists(t *testing.T) {
+	ids := make([]string, 501)
+	for index := range ids {
+		ids[index] = fmt.Sprintf("%036d", index)
+	}
+	var requests []string
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		body := struct {
+			IDs []string `json:"ids"`
+		}{IDs: strings.Split(request.URL.Query().Get("ids"), ",")}
+		if request.Method == http.MethodPost {
+			if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+		}
+		requests = append(requests, fmt.Sprintf("%s %d", request.Method, len(body.IDs)))
+		items := make([]domain.BatchItem, len(body.IDs))
+		for index, id := range body.IDs {
+			items[index] = domain.BatchItem{ID: id}
+		}
+		respondData(t, writer, items)
+	})
+	defer server.Close()
+
+	items, err := dataPlane.GetSignals(ids)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requests) != 2 || requests[0] != "POST 500" || requests[1] != "GET 1" {
+		t.Errorf("expected a POST of 500 ids then a GET of 1, got %v", requests)
+	}
+	if len(items) != len(ids) || items[500].ID != ids[500] {
+		t.Errorf("expected %d items in order, got %d", len(ids), len(items))
+	}
+}
+
+func TestWithFields_SendsFields(t *testing.T) {
+	var queries []string
+	server, dataPlane := fakeServer(func(writer http.ResponseWriter, request *http.Request) {
+		queries = append(queries, request.URL.RawQuery)
+		if request.URL.Path == "/v1/signals/s1" {
+			respondData(t, writer, map[string]string{"id": "s1", "title": "Alert"})
+			return
+		}
+		respondData(t, writer, []map[string]string{{"id": "s1", "title": "Alert"}})
+	})
+	defer server.Close()
+	sparse := dataPlane.WithFields("id", "title")
+
+	signal, err := sparse.GetSignal("s1")
+	_, listErr := sparse.ListSignals("High")
+
+	if err != nil || listErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", err, listErr)
+	}
+	if signal.Title != "Alert" || signal.Priority != "" {
+		t.Errorf("expected only id and title, got %+v", signal)
+	}
+	expected := []string{"fields=id%2Ctitle", "priority=High&fields=id%2Ctitle"}
+	if strings.Join(queries, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected queries %v, got %v", expected, queries)
+	}
+}
@@ -0,0 +1,701 @@
+package config
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/consumer"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/logging"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/ratelimit"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tlsconfig"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tracing"
+	"github.com/redis/go-redis/v9"
+	"gopkg.in/yaml.v3"
+)
+
+// Start offsets accepted by Kafka.StartOffset, used when the consumer group
+// has no committed offset for a partition.
+const (
+	StartOffsetFirst = "first"
+	StartOffsetLast  = "last"
+)
+
+// Roles accepted by Config.Role.
+const (
+	// RoleAll consumes events and serves the read API.
+	RoleAll = "all"
+	// RoleConsumer consumes events and maintains the projection, serving
+	// only health and metrics.
+	RoleConsumer = "consumer"
+	// RoleAPI serves the read API without joining the consumer group.
+	RoleAPI = "api"
+)
+
+// mask replaces secrets in Redacted.
+const mask = "********"
+
+// Config is the data-plane server configuration. ShutdownTimeout bounds how
+// long in-flight events and requests are drained after SIGINT or SIGTERM.
+type Config struct {
+	Role            string        `yaml:"role"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	Kafka           Kafka         `yaml:"kafka"`
+	Redis           Redis         `yaml:"redis"`
+	HTTP            HTTP          `yaml:"http"`
+	Auth            Auth          `yaml:"auth"`
+	RateLimit       RateLimit     `yaml:"rate_limit"`
+	Consumer        Consumer      `yaml:"consumer"`
+	Retention       Retention     `yaml:"retention"`
+	Logging         Logging       `yaml:"logging"`
+	Tracing         Tracing       `yaml:"tracing"`
+}
+
+// Kafka configures the brokers and topics the consumer uses.
+type Kafka struct {
+	Brokers         []string `yaml:"brokers"`
+	Topic           string   `yaml:"topic"`
+	GroupID         string   `yaml:"group_id"`
+	StartOffset     string   `yaml:"start_offset"`
+	DeadLetterTopic string   `yaml:"dead_letter_topic"`
+	TLS             TLS      `yaml:"tls"`
+	SASL            SASL     `yaml:"sasl"`
+}
+
+// SASL configures broker authentication. An empty mechanism disables it.
+type SASL struct {
+	Mechanism string `yaml:"mechanism"`
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+}
+
+// Redis configures the projection store.
+type Redis struct {
+	Addr     string `yaml:"addr"`
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+	DB       int    `yaml:"db"`
+	TLS      TLS    `yaml:"tls"`
+}
+
+// TLS configures the client side of an encrypted connection.
+type TLS struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// Build returns the TLS client configuration, or nil when TLS is disabled.
+func (t TLS) Build() (*tls.Config, error) {
+	if !t.Enabled {
+		return nil, nil
+	}
+	return tlsconfig.Client(tlsconfig.Options{
+		CAFile:             t.CAFile,
+		CertFile:           t.CertFile,
+		KeyFile:            t.KeyFile,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	})
+}
+
+// HTTP configures the read API server.
+type HTTP struct {
+	Addr               string        `yaml:"addr"`
+	ReadHeaderTimeout  time.Duration `yaml:"read_header_timeout"`
+	ReadTimeout        time.Duration `yaml:"read_timeout"`
+	WriteTimeout       time.Duration `yaml:"write_timeout"`
+	IdleTimeout        time.Duration `yaml:"idle_timeout"`
+	ConsistencyTimeout time.Duration `yaml:"consistency_timeout"`
+	TLS                ServerTLS     `yaml:"tls"`
+}
+
+// ServerTLS configures HTTPS for the read API and, optionally, client
+// certificate verification.
+type ServerTLS struct {
+	Enabled        bool          `yaml:"enabled"`
+	CertFile       string        `yaml:"cert_file"`
+	KeyFile        string        `yaml:"key_file"`
+	ClientAuth     string        `yaml:"client_auth"`
+	ClientCAFile   string        `yaml:"client_ca_file"`
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+}
+
+// Auth configures authentication of the read API. When enabled, every route
+// but /health needs an API key or a JWT.
+type Auth struct {
+	Enabled bool     `yaml:"enabled"`
+	APIKeys []APIKey `yaml:"api_keys"`
+	JWT     JWT      `yaml:"jwt"`
+}
+
+// APIKey is a static key, identified by the hex SHA-256 of its value.
+type APIKey struct {
+	Name   string   `yaml:"name"`
+	SHA256 string   `yaml:"sha256"`
+	Scopes []string `yaml:"scopes"`
+	// Role is the most restricted signal visibility the key may read:
+	// public (the default), internal or admin.
+	Role string `yaml:"role"`
+}
+
+// JWT configures JWT verification. An empty JWKS file disables JWTs.
+type JWT struct {
+	JWKSFile string        `yaml:"jwks_file"`
+	Issuer   string        `yaml:"issuer"`
+	Audience string        `yaml:"audience"`
+	Leeway   time.Duration `yaml:"leeway"`
+}
+
+// Build returns the authenticator, or nil when authentication is disabled.
+func (a Auth) Build() (*auth.Authenticator, error) {
+	if !a.Enabled {
+		return nil, nil
+	}
+	var verifier *auth.JWTVerifier
+	if a.JWT.JWKSFile != "" {
+		var err error
+		verifier, err = auth.NewJWTVerifier(auth.JWTOptions{
+			JWKSFile: a.JWT.JWKSFile,
+			Issuer:   a.JWT.Issuer,
+			Audience: a.JWT.Audience,
+			Leeway:   a.JWT.Leeway,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	authenticator, err := auth.New(a.apiKeys(), verifier)
+	if err != nil {
+		return nil, err
+	}
+	return &authenticator, nil
+}
+
+// RateLimit configures per-caller token buckets on the read routes, shared
+// by every instance through Redis. Callers hold up to Burst requests,
+// refilled at Requests per Period.
+type RateLimit struct {
+	Enabled  bool          `yaml:"enabled"`
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+	Burst    int           `yaml:"burst"`
+	// Clients override the limit of callers by identity: an API key's
+	// name, a JWT's subject or a client certificate's common name.
+	Clients []ClientRateLimit `yaml:"clients"`
+}
+
+// ClientRateLimit is the limit of a single caller, refilled over
+// RateLimit.Period.
+type ClientRateLimit struct {
+	Name     string `yaml:"name"`
+	Requests int    `yaml:"requests"`
+	Burst    int    `yaml:"burst"`
+}
+
+// Build creates the limiter storing its buckets through client, or returns
+// nil when rate limiting is disabled.
+func (r RateLimit) Build(client *redis.Client) *ratelimit.Limiter {
+	if !r.Enabled {
+		return nil
+	}
+	options := make([]ratelimit.Option, 0, len(r.Clients))
+	for _, limit := range r.Clients {
+		options = append(options, ratelimit.WithClientLimit(limit.Name,
+			ratelimit.Limit{Requests: limit.Requests, Period: r.Period, Burst: limit.Burst}))
+	}
+	limiter := ratelimit.New(client, ratelimit.Limit{Requests: r.Requests, Period: r.Period, Burst: r.Burst}, options...)
+	return &limiter
+}
+
+func (a Auth) apiKeys() []auth.APIKey {
+	keys := make([]auth.APIKey, 0, len(a.APIKeys))
+	for _, key := range a.APIKeys {
+		keys = append(keys, auth.APIKey{Name: key.Name, SHA256: key.SHA256, Scopes: key.Scopes, Role: key.Role})
+	}
+	return keys
+}
+
+// Consumer tunes how events are fetched, decoded and retried.
+type Consumer struct {
+	ValidationPolicy  string        `yaml:"validation_policy"`
+	SchemaRegistryDir string        `yaml:"schema_registry_dir"`
+	RetryInterval     time.Duration `yaml:"retry_interval"`
+	MaxWait           time.Duration `yaml:"max_wait"`
+	MaxBytes          int           `yaml:"max_bytes"`
+}
+
+// Retention configures the retention sweeper. Zero limits disable a rule.
+type Retention struct {
+	MaxAge         time.Duration `yaml:"max_age"`
+	MaxPerPriority int           `yaml:"max_per_priority"`
+	Interval       time.Duration `yaml:"interval"`
+}
+
+// Logging configures the default logger.
+type Logging struct {
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+// Tracing configures span export.
+type Tracing struct {
+	Exporter string `yaml:"exporter"`
+	File     string `yaml:"file"`
+}
+
+// Default returns the configuration used when nothing overrides it.
+func Default() Config {
+	return Config{
+		Role:            RoleAll,
+		ShutdownTimeout: 30 * time.Second,
+		Kafka: Kafka{
+			Brokers:         []string{"localhost:9092"},
+			Topic:           consumer.Topic,
+			GroupID:         consumer.GroupID,
+			StartOffset:     StartOffsetFirst,
+			DeadLetterTopic: consumer.DeadLetterTopic,
+		},
+		Redis: Redis{Addr: "localhost:6379"},
+		HTTP: HTTP{
+			Addr:               ":8081",
+			ReadHeaderTimeout:  5 * time.Second,
+			ReadTimeout:        10 * time.Second,
+			WriteTimeout:       30 * time.Second,
+			IdleTimeout:        2 * time.Minute,
+			ConsistencyTimeout: 2 * time.Second,
+			TLS: ServerTLS{
+				ClientAuth:     tlsconfig.ClientAuthNone,
+				ReloadInterval: 30 * time.Second,
+			},
+		},
+		Auth:      Auth{JWT: JWT{Leeway: 30 * time.Second}},
+		RateLimit: RateLimit{Requests: 10, Period: time.Second, Burst: 20},
+		Consumer: Consumer{
+			ValidationPolicy: string(consumer.PolicyReject),
+			RetryInterval:    consumer.DefaultRetryInterval,
+			MaxWait:          10 * time.Second,
+			MaxBytes:         1 << 20,
+		},
+		Retention: Retention{Interval: time.Minute},
+		Logging:   Logging{Level: "info", Format: logging.FormatText},
+		Tracing:   Tracing{Exporter: tracing.ExporterNone, File: "traces.jsonl"},
+	}
+}
+
+// Load builds the configuration from the defaults, the YAML file at path
+// (skipped when path is empty), then the environment variables getenv
+// returns, and validates the result. Empty variables are treated as unset.
+func Load(path string, getenv func(string) string) (Config, error) {
+	config := Default()
+	if path != "" {
+		if err := config.readFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+	if err := config.applyEnv(getenv); err != nil {
+		return Config{}, err
+	}
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// readFile overlays the YAML file at path. Unknown keys are rejected so a
+// misspelled option fails instead of silently keeping its default.
+func (c *Config) readFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv overlays the environment variables the server has always read,
+// plus one per remaining option. Every malformed value is reported.
+func (c *Config) applyEnv(getenv func(string) string) error {
+	env := environment{getenv: getenv}
+
+	env.string("ROLE", &c.Role)
+	env.duration("SHUTDOWN_TIMEOUT", &c.ShutdownTimeout)
+
+	if brokers := getenv("KAFKA_BROKERS"); brokers != "" {
+		c.Kafka.Brokers = splitList(brokers)
+	}
+	env.string("KAFKA_TOPIC", &c.Kafka.Topic)
+	env.string("KAFKA_GROUP_ID", &c.Kafka.GroupID)
+	env.string("KAFKA_START_OFFSET", &c.Kafka.StartOffset)
+	env.string("DEAD_LETTER_TOPIC", &c.Kafka.DeadLetterTopic)
+	env.tls("KAFKA_TLS_", &c.Kafka.TLS)
+	env.string("KAFKA_SASL_MECHANISM", &c.Kafka.SASL.Mechanism)
+	env.string("KAFKA_SASL_USERNAME", &c.Kafka.SASL.Username)
+	env.string("KAFKA_SASL_PASSWORD", &c.Kafka.SASL.Password)
+
+	env.string("REDIS_ADDR", &c.Redis.Addr)
+	env.string("REDIS_USERNAME", &c.Redis.Username)
+	env.string("REDIS_PASSWORD", &c.Redis.Password)
+	env.int("REDIS_DB", &c.Redis.DB)
+	env.tls("REDIS_TLS_", &c.Redis.TLS)
+
+	env.string("HTTP_ADDR", &c.HTTP.Addr)
+	env.duration("HTTP_READ_HEADER_TIMEOUT", &c.HTTP.ReadHeaderTimeout)
+	env.duration("HTTP_READ_TIMEOUT", &c.HTTP.ReadTimeout)
+	env.duration("HTTP_WRITE_TIMEOUT", &c.HTTP.WriteTimeout)
+	env.duration("HTTP_IDLE_TIMEOUT", &c.HTTP.IdleTimeout)
+	env.duration("CONSISTENCY_TIMEOUT", &c.HTTP.ConsistencyTimeout)
+	env.bool("HTTP_TLS_ENABLED", &c.HTTP.TLS.Enabled)
+	env.string("HTTP_TLS_CERT_FILE", &c.HTTP.TLS.CertFile)
+	env.string("HTTP_TLS_KEY_FILE", &c.HTTP.TLS.KeyFile)
+	env.string("HTTP_TLS_CLIENT_AUTH", &c.HTTP.TLS.ClientAuth)
+	env.string("HTTP_TLS_CLIENT_CA_FILE", &c.HTTP.TLS.ClientCAFile)
+	env.duration("HTTP_TLS_RELOAD_INTERVAL", &c.HTTP.TLS.ReloadInterval)
+
+	env.bool("AUTH_ENABLED", &c.Auth.Enabled)
+	env.apiKeys("AUTH_API_KEYS", &c.Auth.APIKeys)
+	env.string("AUTH_JWT_JWKS_FILE", &c.Auth.JWT.JWKSFile)
+	env.string("AUTH_JWT_ISSUER", &c.Auth.JWT.Issuer)
+	env.string("AUTH_JWT_AUDIENCE", &c.Auth.JWT.Audience)
+	env.duration("AUTH_JWT_LEEWAY", &c.Auth.JWT.Leeway)
+
+	env.bool("RATE_LIMIT_ENABLED", &c.RateLimit.Enabled)
+	env.int("RATE_LIMIT_REQUESTS", &c.RateLimit.Requests)
+	env.duration("RATE_LIMIT_PERIOD", &c.RateLimit.Period)
+	env.int("RATE_LIMIT_BURST", &c.RateLimit.Burst)
+
+	env.string("VALIDATION_POLICY", &c.Consumer.ValidationPolicy)
+	env.string("SCHEMA_REGISTRY_DIR", &c.Consumer.SchemaRegistryDir)
+	env.duration("CONSUMER_RETRY_INTERVAL", &c.Consumer.RetryInterval)
+	env.duration("CONSUMER_MAX_WAIT", &c.Consumer.MaxWait)
+	env.int("CONSUMER_MAX_BYTES", &c.Consumer.MaxBytes)
+
+	env.duration("RETENTION_MAX_AGE", &c.Retention.MaxAge)
+	env.int("RETENTION_MAX_PER_PRIORITY", &c.Retention.MaxPerPriority)
+	env.duration("RETENTION_INTERVAL", &c.Retention.Interval)
+
+	env.string("LOG_LEVEL", &c.Logging.Level)
+	env.string("LOG_FORMAT", &c.Logging.Format)
+
+	env.string("TRACING_EXPORTER", &c.Tracing.Exporter)
+	env.string("TRACING_FILE", &c.Tracing.File)
+
+	return errors.Join(env.errs...)
+}
+
+// Validate reports every invalid option at once.
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Role == RoleAll || c.Role == RoleConsumer || c.Role == RoleAPI,
+		"role: %q is not %q, %q or %q", c.Role, RoleAll, RoleConsumer, RoleAPI)
+	check(c.ShutdownTimeout > 0, "shutdown_timeout: must be positive")
+
+	check(len(c.Kafka.Brokers) > 0, "kafka.brokers: at least one broker is required")
+	for i, broker := range c.Kafka.Brokers {
+		check(broker != "", "kafka.brokers[%d]: must not be empty", i)
+	}
+	check(c.Kafka.Topic != "", "kafka.topic: required")
+	check(c.Kafka.GroupID != "", "kafka.group_id: required")
+	check(c.Kafka.StartOffset == StartOffsetFirst || c.Kafka.StartOffset == StartOffsetLast,
+		"kafka.start_offset: %q is not %q or %q", c.Kafka.StartOffset, StartOffsetFirst, StartOffsetLast)
+	check(c.Kafka.DeadLetterTopic != "", "kafka.dead_letter_topic: required")
+	check(c.Kafka.DeadLetterTopic != c.Kafka.Topic, "kafka.dead_letter_topic: must differ from kafka.topic")
+
+	if _, err := consumer.NewSASLMechanism(c.Kafka.SASL.Mechanism, c.Kafka.SASL.Username, c.Kafka.SASL.Password); err != nil {
+		errs = append(errs, fmt.Errorf("kafka.sasl.mechanism: %w", err))
+	}
+	check(c.Kafka.SASL.Mechanism == "" || c.Kafka.SASL.Username != "", "kafka.sasl.username: required by kafka.sasl.mechanism")
+	errs = append(errs, c.Kafka.TLS.validate("kafka.tls")...)
+
+	check(c.Redis.Addr != "", "redis.addr: required")
+	check(c.Redis.DB >= 0, "redis.db: must not be negative")
+	check(c.Redis.Username == "" || c.Redis.Password != "", "redis.password: required by redis.username")
+	errs = append(errs, c.Redis.TLS.validate("redis.tls")...)
+
+	check(c.HTTP.Addr != "", "http.addr: required")
+	check(c.HTTP.ReadHeaderTimeout >= 0, "http.read_header_timeout: must not be negative")
+	check(c.HTTP.ReadTimeout >= 0, "http.read_timeout: must not be negative")
+	check(c.HTTP.WriteTimeout >= 0, "http.write_timeout: must not be negative")
+	check(c.HTTP.IdleTimeout >= 0, "http.idle_timeout: must not be negative")
+	check(c.HTTP.ConsistencyTimeout >= 0, "http.consistency_timeout: must not be negative")
+	check(c.HTTP.WriteTimeout == 0 || c.HTTP.WriteTimeout > c.HTTP.ConsistencyTimeout,
+		"http.write_timeout: must exceed http.consistency_timeout so waiting reads can answer")
+	errs = append(errs, c.HTTP.TLS.validate()...)
+	errs = append(errs, c.Auth.validate()...)
+	errs = append(errs, c.RateLimit.validate()...)
+
+	if _, err := consumer.ParseValidationPolicy(c.Consumer.ValidationPolicy); err != nil {
+		errs = append(errs, fmt.Errorf("consumer.validation_policy: %w", err))
+	}
+	check(c.Consumer.RetryInterval > 0, "consumer.retry_interval: must be positive")
+	check(c.Consumer.MaxWait > 0, "consumer.max_wait: must be positive")
+	check(c.Consumer.MaxBytes > 0, "consumer.max_bytes: must be positive")
+
+	check(c.Retention.MaxAge >= 0, "retention.max_age: must not be negative")
+	check(c.Retention.MaxPerPriority >= 0, "retention.max_per_priority: must not be negative")
+	check(c.Retention.Interval > 0, "retention.interval: must be positive")
+
+	if _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		errs = append(errs, fmt.Errorf("logging.level: %w", err))
+	}
+	check(c.Logging.Format == logging.FormatText || c.Logging.Format == logging.FormatJSON,
+		"logging.format: %q is not %q or %q", c.Logging.Format, logging.FormatText, logging.FormatJSON)
+
+	switch c.Tracing.Exporter {
+	case tracing.ExporterNone, tracing.ExporterStdout:
+	case tracing.ExporterOTLPFile:
+		check(c.Tracing.File != "", "tracing.file: required by the %q exporter", tracing.ExporterOTLPFile)
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter: %q is not %q, %q or %q",
+			c.Tracing.Exporter, tracing.ExporterNone, tracing.ExporterStdout, tracing.ExporterOTLPFile))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t ServerTLS) validate() []error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	switch t.ClientAuth {
+	case tlsconfig.ClientAuthNone, tlsconfig.ClientAuthOptional, tlsconfig.ClientAuthRequire:
+	default:
+		check(false, "http.tls.client_auth: %q is not %q, %q or %q",
+			t.ClientAuth, tlsconfig.ClientAuthNone, tlsconfig.ClientAuthOptional, tlsconfig.ClientAuthRequire)
+	}
+	if !t.Enabled {
+		check(t.CertFile == "" && t.KeyFile == "" && t.ClientCAFile == "" && t.ClientAuth == tlsconfig.ClientAuthNone,
+			"http.tls.enabled: must be true when other http.tls options are set")
+		return errs
+	}
+	check(t.CertFile != "" && t.KeyFile != "", "http.tls: cert_file and key_file are required")
+	check(t.ClientAuth == tlsconfig.ClientAuthNone || t.ClientCAFile != "",
+		"http.tls.client_ca_file: required by client_auth %q", t.ClientAuth)
+	check(t.ReloadInterval > 0, "http.tls.reload_interval: must be positive")
+	return errs
+}
+
+func (a Auth) validate() []error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	check(a.JWT.Leeway >= 0, "auth.jwt.leeway: must not be negative")
+	if !a.Enabled {
+		check(len(a.APIKeys) == 0 && a.JWT.JWKSFile == "",
+			"auth.enabled: must be true when api_keys or jwt.jwks_file are set")
+		return errs
+	}
+	check(len(a.APIKeys) > 0 || a.JWT.JWKSFile != "",
+		"auth: api_keys or jwt.jwks_file is required, or every request would be refused")
+	names := make(map[string]bool, len(a.APIKeys))
+	for i, key := range a.APIKeys {
+		check(key.Name != "", "auth.api_keys[%d].name: required", i)
+		check(key.Name == "" || !names[key.Name], "auth.api_keys[%d].name: %q is used by another key", i, key.Name)
+		check(key.Role == "" || slices.Contains(domain.Visibilities(), key.Role),
+			"auth.api_keys[%d].role: %q is not %q, %q or %q", i, key.Role,
+			domain.VisibilityPublic, domain.VisibilityInternal, domain.VisibilityAdmin)
+		names[key.Name] = true
+	}
+	if _, err := auth.New(a.apiKeys(), nil); err != nil {
+		errs = append(errs, fmt.Errorf("auth.api_keys: %w", err))
+	}
+	check(a.JWT.JWKSFile != "" || (a.JWT.Issuer == "" && a.JWT.Audience == ""),
+		"auth.jwt.jwks_file: required by auth.jwt.issuer and auth.jwt.audience")
+	return errs
+}
+
+func (r RateLimit) validate() []error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+	check(r.Requests > 0, "rate_limit.requests: must be positive")
+	check(r.Period > 0, "rate_limit.period: must be positive")
+	check(r.Burst > 0, "rate_limit.burst: must be positive")
+	if !r.Enabled {
+		check(len(r.Clients) == 0, "rate_limit.enabled: must be true when clients are set")
+		return errs
+	}
+	names := make(map[string]bool, len(r.Clients))
+	for i, limit := range r.Clients {
+		check(limit.Name != "", "rate_limit.clients[%d].name: required", i)
+		check(limit.Name == "" || !names[limit.Name], "rate_limit.clients[%d].name: %q is used by another client", i, limit.Name)
+		check(limit.Requests > 0, "rate_limit.clients[%d].requests: must be positive", i)
+		check(limit.Burst > 0, "rate_limit.clients[%d].burst: must be positive", i)
+		names[limit.Name] = true
+	}
+	return errs
+}
+
+// validate checks the TLS options under the given key prefix.
+func (t TLS) validate(prefix string) []error {
+	var errs []error
+	if !t.Enabled {
+		if t.CAFile != "" || t.CertFile != "" || t.KeyFile != "" || t.ServerName != "" || t.InsecureSkipVerify {
+			errs = append(errs, fmt.Errorf("%s.enabled: must be true when other %s options are set", prefix, prefix))
+		}
+		return errs
+	}
+	if (t.CertFile == "") != (t.KeyFile == "") {
+		errs = append(errs, fmt.Errorf("%s: cert_file and key_file must be set together", prefix))
+	}
+	return errs
+}
+
+// Consumes reports whether the role runs the consumer and the background
+// work that writes to the projection.
+func (c Config) Consumes() bool {
+	return c.Role == RoleAll || c.Role == RoleConsumer
+}
+
+// ServesReads reports whether the role serves the read API.
+func (c Config) ServesReads() bool {
+	return c.Role == RoleAll || c.Role == RoleAPI
+}
+
+// Redacted returns a copy with secrets masked, safe to print or log.
+func (c Config) Redacted() Config {
+	if c.Redis.Password != "" {
+		c.Redis.Password = mask
+	}
+	if c.Kafka.SASL.Password != "" {
+		c.Kafka.SASL.Password = mask
+	}
+	return c
+}
+
+// YAML renders the configuration in the file format Load reads.
+func (c Config) YAML() ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// environment reads typed variables, collecting parse errors.
+type environment struct {
+	getenv func(string) string
+	errs   []error
+}
+
+func (e *environment) string(key string, target *string) {
+	if value := e.getenv(key); value != "" {
+		*target = value
+	}
+}
+
+func (e *environment) bool(key string, target *bool) {
+	value := e.getenv(key)
+	if value == "" {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a boolean", key, value))
+		return
+	}
+	*target = parsed
+}
+
+// tls reads the TLS options under prefix, e.g. KAFKA_TLS_CA_FILE.
+func (e *environment) tls(prefix string, target *TLS) {
+	e.bool(prefix+"ENABLED", &target.Enabled)
+	e.string(prefix+"CA_FILE", &target.CAFile)
+	e.string(prefix+"CERT_FILE", &target.CertFile)
+	e.string(prefix+"KEY_FILE", &target.KeyFile)
+	e.string(prefix+"SERVER_NAME", &target.ServerName)
+	e.bool(prefix+"INSECURE_SKIP_VERIFY", &target.InsecureSkipVerify)
+}
+
+// apiKeys reads comma-separated name:sha256[:role] entries, granting each
+// key the default scopes.
+func (e *environment) apiKeys(key string, target *[]APIKey) {
+	value := e.getenv(key)
+	if value == "" {
+		return
+	}
+	var keys []APIKey
+	for _, entry := range splitList(value) {
+		parts := strings.Split(entry, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			e.errs = append(e.errs, fmt.Errorf("%s: %q is not a name:sha256[:role] entry", key, entry))
+			return
+		}
+		apiKey := APIKey{Name: parts[0], SHA256: parts[1]}
+		if len(parts) == 3 {
+			apiKey.Role = parts[2]
+		}
+		keys = append(keys, apiKey)
+	}
+	*target = keys
+}
+
+func (e *environment) int(key string, target *int) {
+	value := e.getenv(key)
+	if value == "" {
+		return
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not an integer", key, value))
+		return
+	}
+	*target = number
+}
+
+func (e *environment) duration(key string, target *time.Duration) {
+	value := e.getenv(key)
+	if value == "" {
+		return
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a duration", key, value))
+		return
+	}
+	*target = duration
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		items = append(items, strings.TrimSpace(item))
+	}
+	return items
+}
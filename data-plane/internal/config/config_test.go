@@ -0,0 +1,359 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/config"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tracing"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func envOf(values map[string]string) func(string) string {
+	return func(key string) string { return values[key] }
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	cfg, err := config.Load("", envOf(nil))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Kafka.Topic != "nexus.signals" || cfg.Kafka.GroupID != "nexus-data-plane" {
+		t.Errorf("expected the default topic and group, got %+v", cfg.Kafka)
+	}
+	if cfg.HTTP.Addr != ":8081" || cfg.HTTP.ConsistencyTimeout != 2*time.Second {
+		t.Errorf("expected the default HTTP settings, got %+v", cfg.HTTP)
+	}
+}
+
+func TestLoad_FileThenEnv(t *testing.T) {
+	path := writeFile(t, `
+kafka:
+  brokers: [kafka-1:9092, kafka-2:9092]
+  topic: signals.v2
+  start_offset: last
+http:
+  addr: ":9000"
+  write_timeout: 45s
+retention:
+  max_age: 720h
+`)
+	env := envOf(map[string]string{"HTTP_ADDR": ":9100", "REDIS_DB": "3"})
+
+	cfg, err := config.Load(path, env)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Kafka.Brokers) != 2 || cfg.Kafka.Topic != "signals.v2" || cfg.Kafka.StartOffset != config.StartOffsetLast {
+		t.Errorf("expected kafka settings from the file, got %+v", cfg.Kafka)
+	}
+	if cfg.Kafka.GroupID != "nexus-data-plane" {
+		t.Errorf("expected keys missing from the file to keep their default, got %q", cfg.Kafka.GroupID)
+	}
+	if cfg.HTTP.Addr != ":9100" {
+		t.Errorf("expected the environment to override the file, got %q", cfg.HTTP.Addr)
+	}
+	if cfg.HTTP.WriteTimeout != 45*time.Second || cfg.Retention.MaxAge != 720*time.Hour {
+		t.Errorf("expected durations parsed from the file, got %v and %v", cfg.HTTP.WriteTimeout, cfg.Retention.MaxAge)
+	}
+	if cfg.Redis.DB != 3 {
+		t.Errorf("expected redis db 3, got %d", cfg.Redis.DB)
+	}
+}
+
+func TestLoad_Role(t *testing.T) {
+	tests := map[string]struct {
+		consumes, servesReads bool
+	}{
+		config.RoleAll:      {consumes: true, servesReads: true},
+		config.RoleConsumer: {consumes: true, servesReads: false},
+		config.RoleAPI:      {consumes: false, servesReads: true},
+	}
+
+	for role, expected := range tests {
+		t.Run(role, func(t *testing.T) {
+			cfg, err := config.Load("", envOf(map[string]string{"ROLE": role}))
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Consumes() != expected.consumes || cfg.ServesReads() != expected.servesReads {
+				t.Errorf("expected consumes=%t servesReads=%t, got %t and %t",
+					expected.consumes, expected.servesReads, cfg.Consumes(), cfg.ServesReads())
+			}
+		})
+	}
+}
+
+func TestLoad_BrokerList(t *testing.T) {
+	cfg, err := config.Load("", envOf(map[string]string{"KAFKA_BROKERS": "a:9092, b:9092"}))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(cfg.Kafka.Brokers, ",") != "a:9092,b:9092" {
+		t.Errorf("expected two trimmed brokers, got %q", cfg.Kafka.Brokers)
+	}
+}
+
+func TestLoad_UnknownKey(t *testing.T) {
+	path := writeFile(t, "kafka:\n  topik: signals\n")
+
+	_, err := config.Load(path, envOf(nil))
+
+	if err == nil || !strings.Contains(err.Error(), "topik") {
+		t.Errorf("expected an error naming the unknown key, got %v", err)
+	}
+}
+
+func TestLoad_MalformedEnv(t *testing.T) {
+	env := envOf(map[string]string{"RETENTION_INTERVAL": "soon", "REDIS_DB": "one"})
+
+	_, err := config.Load("", env)
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, key := range []string{"RETENTION_INTERVAL", "REDIS_DB"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("expected the error to name %s, got %v", key, err)
+		}
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg := config.Default()
+	cfg.Role = "reader"
+	cfg.ShutdownTimeout = 0
+	cfg.Kafka.Brokers = nil
+	cfg.Kafka.StartOffset = "middle"
+	cfg.Kafka.DeadLetterTopic = cfg.Kafka.Topic
+	cfg.HTTP.WriteTimeout = time.Second
+	cfg.Consumer.ValidationPolicy = "ignore"
+	cfg.Logging.Level = "loud"
+	cfg.Tracing.Exporter = tracing.ExporterOTLPFile
+	cfg.Tracing.File = ""
+
+	err := cfg.Validate()
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, field := range []string{
+		"role", "shutdown_timeout", "kafka.brokers", "kafka.start_offset", "kafka.dead_letter_topic", "http.write_timeout",
+		"consumer.validation_policy", "logging.level", "tracing.file",
+	} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected the error to name %s, got %v", field, err)
+		}
+	}
+}
+
+func TestLoad_SecureConnections(t *testing.T) {
+	env := envOf(map[string]string{
+		"KAFKA_TLS_ENABLED":    "true",
+		"KAFKA_TLS_CA_FILE":    "/etc/kafka/ca.pem",
+		"KAFKA_SASL_MECHANISM": "scram-sha-512",
+		"KAFKA_SASL_USERNAME":  "data-plane",
+		"KAFKA_SASL_PASSWORD":  "s3cret",
+		"REDIS_USERNAME":       "projection",
+		"REDIS_PASSWORD":       "s3cret",
+		"REDIS_TLS_ENABLED":    "1",
+	})
+
+	cfg, err := config.Load("", env)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.Kafka.TLS.Enabled || cfg.Kafka.TLS.CAFile != "/etc/kafka/ca.pem" || cfg.Kafka.SASL.Mechanism != "scram-sha-512" {
+		t.Errorf("expected kafka TLS and SASL from the environment, got %+v", cfg.Kafka)
+	}
+	if !cfg.Redis.TLS.Enabled || cfg.Redis.Username != "projection" {
+		t.Errorf("expected redis TLS and ACL user from the environment, got %+v", cfg.Redis)
+	}
+}
+
+func TestValidate_SecureConnections(t *testing.T) {
+	cfg := config.Default()
+	cfg.Kafka.TLS.CAFile = "/etc/kafka/ca.pem"
+	cfg.Kafka.SASL.Mechanism = "kerberos"
+	cfg.Redis.Username = "projection"
+	cfg.Redis.TLS = config.TLS{Enabled: true, CertFile: "/etc/redis/client.pem"}
+
+	err := cfg.Validate()
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	for _, field := range []string{"kafka.tls.enabled", "kafka.sasl.mechanism", "kafka.sasl.username", "redis.password", "redis.tls"} {
+		if !strings.Contains(err.Error(), field) {
+			t.Errorf("expected the error to name %s, got %v", field, err)
+		}
+	}
+}
+
+func TestValidate_ServerTLS(t *testing.T) {
+	tests := map[string]struct {
+		tls   config.ServerTLS
+		field string
+	}{
+		"options while disabled": {config.ServerTLS{ClientAuth: "require", ReloadInterval: time.Second}, "http.tls.enabled"},
+		"missing key pair":       {config.ServerTLS{Enabled: true, ClientAuth: "none", ReloadInterval: time.Second}, "cert_file and key_file"},
+		"client auth without CA": {config.ServerTLS{Enabled: true, CertFile: "c", KeyFile: "k", ClientAuth: "require", ReloadInterval: time.Second}, "http.tls.client_ca_file"},
+		"unknown client auth":    {config.ServerTLS{Enabled: true, CertFile: "c", KeyFile: "k", ClientAuth: "maybe", ReloadInterval: time.Second}, "http.tls.client_auth"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.HTTP.TLS = test.tls
+
+			err := cfg.Validate()
+
+			if err == nil || !strings.Contains(err.Error(), test.field) {
+				t.Errorf("expected an error naming %s, got %v", test.field, err)
+			}
+		})
+	}
+}
+
+func TestLoad_APIKeysFromEnv(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	env := map[string]string{"AUTH_ENABLED": "true", "AUTH_API_KEYS": "dashboard:" + hash + ", ci:" + hash[:62] + "cd:internal"}
+
+	cfg, err := config.Load("", func(key string) string { return env[key] })
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.Auth.APIKeys) != 2 || cfg.Auth.APIKeys[1].Name != "ci" || cfg.Auth.APIKeys[0].SHA256 != hash {
+		t.Errorf("expected two parsed keys, got %+v", cfg.Auth.APIKeys)
+	}
+	if cfg.Auth.APIKeys[0].Role != "" || cfg.Auth.APIKeys[1].Role != "internal" {
+		t.Errorf("expected only the second key to carry a role, got %+v", cfg.Auth.APIKeys)
+	}
+}
+
+func TestValidate_Auth(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	tests := map[string]struct {
+		auth  config.Auth
+		field string
+	}{
+		"keys while disabled":   {config.Auth{APIKeys: []config.APIKey{{Name: "a", SHA256: hash}}}, "auth.enabled"},
+		"no credentials":        {config.Auth{Enabled: true}, "api_keys or jwt.jwks_file is required"},
+		"unnamed key":           {config.Auth{Enabled: true, APIKeys: []config.APIKey{{SHA256: hash}}}, "auth.api_keys[0].name"},
+		"duplicate name":        {config.Auth{Enabled: true, APIKeys: []config.APIKey{{Name: "a", SHA256: hash}, {Name: "a", SHA256: strings.Repeat("cd", 32)}}}, "auth.api_keys[1].name"},
+		"plain key, not a hash": {config.Auth{Enabled: true, APIKeys: []config.APIKey{{Name: "a", SHA256: "nxs_secret"}}}, "auth.api_keys"},
+		"issuer without JWKS":   {config.Auth{Enabled: true, APIKeys: []config.APIKey{{Name: "a", SHA256: hash}}, JWT: config.JWT{Issuer: "x"}}, "auth.jwt.jwks_file"},
+		"unknown role":          {config.Auth{Enabled: true, APIKeys: []config.APIKey{{Name: "a", SHA256: hash, Role: "root"}}}, "auth.api_keys[0].role"},
+		"negative leeway":       {config.Auth{Enabled: true, JWT: config.JWT{JWKSFile: "jwks.json", Leeway: -time.Second}}, "auth.jwt.leeway"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Auth = test.auth
+
+			err := cfg.Validate()
+
+			if err == nil || !strings.Contains(err.Error(), test.field) {
+				t.Errorf("expected an error naming %s, got %v", test.field, err)
+			}
+		})
+	}
+}
+
+func TestValidate_RateLimit(t *testing.T) {
+	tests := map[string]struct {
+		rateLimit config.RateLimit
+		field     string
+	}{
+		"no requests":            {config.RateLimit{Period: time.Second, Burst: 1}, "rate_limit.requests"},
+		"no period":              {config.RateLimit{Requests: 1, Burst: 1}, "rate_limit.period"},
+		"no burst":               {config.RateLimit{Requests: 1, Period: time.Second}, "rate_limit.burst"},
+		"clients while disabled": {config.RateLimit{Requests: 1, Period: time.Second, Burst: 1, Clients: []config.ClientRateLimit{{Name: "a", Requests: 1, Burst: 1}}}, "rate_limit.enabled"},
+		"unnamed client":         {config.RateLimit{Enabled: true, Requests: 1, Period: time.Second, Burst: 1, Clients: []config.ClientRateLimit{{Requests: 1, Burst: 1}}}, "rate_limit.clients[0].name"},
+		"duplicate client":       {config.RateLimit{Enabled: true, Requests: 1, Period: time.Second, Burst: 1, Clients: []config.ClientRateLimit{{Name: "a", Requests: 1, Burst: 1}, {Name: "a", Requests: 2, Burst: 2}}}, "rate_limit.clients[1].name"},
+		"client without burst":   {config.RateLimit{Enabled: true, Requests: 1, Period: time.Second, Burst: 1, Clients: []config.ClientRateLimit{{Name: "a", Requests: 1}}}, "rate_limit.clients[0].burst"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.RateLimit = test.rateLimit
+
+			err := cfg.Validate()
+
+			if err == nil || !strings.Contains(err.Error(), test.field) {
+				t.Errorf("expected an error naming %s, got %v", test.field, err)
+			}
+		})
+	}
+}
+
+func TestTLS_Build(t *testing.T) {
+	disabled, err := config.TLS{}.Build()
+	if err != nil || disabled != nil {
+		t.Errorf("expected no TLS config when disabled, got %v and %v", disabled, err)
+	}
+
+	enabled, err := config.TLS{Enabled: true, ServerName: "kafka.internal"}.Build()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enabled == nil || enabled.ServerName != "kafka.internal" {
+		t.Errorf("expected a TLS config for kafka.internal, got %+v", enabled)
+	}
+}
+
+func TestRedacted_MasksSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.Redis.Password = "hunter2"
+	cfg.Kafka.SASL.Password = "swordfish"
+
+	content, err := cfg.Redacted().YAML()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(string(content), "hunter2") || strings.Contains(string(content), "swordfish") {
+		t.Errorf("expected the passwords to be masked, got:\n%s", content)
+	}
+	if cfg.Redis.Password != "hunter2" {
+		t.Error("expected Redacted to leave the original untouched")
+	}
+}
+
+func TestYAML_RoundTrips(t *testing.T) {
+	cfg := config.Default()
+	cfg.Retention.MaxPerPriority = 500
+	content, err := cfg.YAML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := config.Load(writeFile(t, string(content)), envOf(nil))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.Retention.MaxPerPriority != 500 || loaded.HTTP.IdleTimeout != cfg.HTTP.IdleTimeout {
+		t.Errorf("expected the printed config to load back unchanged, got %+v", loaded)
+	}
+}
@@ -0,0 +1,68 @@
+package consumer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+// SASL mechanisms accepted by NewSASLMechanism.
+const (
+	SASLPlain       = "plain"
+	SASLScramSHA256 = "scram-sha-256"
+	SASLScramSHA512 = "scram-sha-512"
+)
+
+// dialTimeout matches kafka-go's default dialer.
+const dialTimeout = 10 * time.Second
+
+// Connection holds the brokers and security settings shared by the reader,
+// the dead-letter writer and the offset client.
+type Connection struct {
+	Brokers []string
+	// TLS encrypts broker connections when set.
+	TLS *tls.Config
+	// SASL authenticates broker connections when set.
+	SASL sasl.Mechanism
+}
+
+// Dialer returns a dialer for kafka.ReaderConfig.
+func (c Connection) Dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		Timeout:       dialTimeout,
+		DualStack:     true,
+		TLS:           c.TLS,
+		SASLMechanism: c.SASL,
+	}
+}
+
+// Transport returns a transport for kafka.Writer and kafka.Client.
+func (c Connection) Transport() *kafka.Transport {
+	return &kafka.Transport{
+		DialTimeout: dialTimeout,
+		TLS:         c.TLS,
+		SASL:        c.SASL,
+	}
+}
+
+// NewSASLMechanism builds the named mechanism. An empty name disables SASL
+// and returns nil.
+func NewSASLMechanism(mechanism, username, password string) (sasl.Mechanism, error) {
+	switch mechanism {
+	case "":
+		return nil, nil
+	case SASLPlain:
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case SASLScramSHA256:
+		return scram.Mechanism(scram.SHA256, username, password)
+	case SASLScramSHA512:
+		return scram.Mechanism(scram.SHA512, username, password)
+	}
+	return nil, fmt.Errorf("unknown SASL mechanism %q (want %q, %q or %q)",
+		mechanism, SASLPlain, SASLScramSHA256, SASLScramSHA512)
+}
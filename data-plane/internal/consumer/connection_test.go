@@ -0,0 +1,66 @@
+package consumer_test
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/consumer"
+)
+
+func TestNewSASLMechanism(t *testing.T) {
+	tests := map[string]string{
+		consumer.SASLPlain:       "PLAIN",
+		consumer.SASLScramSHA256: "SCRAM-SHA-256",
+		consumer.SASLScramSHA512: "SCRAM-SHA-512",
+	}
+
+	for mechanism, expected := range tests {
+		t.Run(mechanism, func(t *testing.T) {
+			built, err := consumer.NewSASLMechanism(mechanism, "data-plane", "s3cret")
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if built.Name() != expected {
+				t.Errorf("expected mechanism %s, got %s", expected, built.Name())
+			}
+		})
+	}
+}
+
+func TestNewSASLMechanism_Disabled(t *testing.T) {
+	built, err := consumer.NewSASLMechanism("", "", "")
+
+	if err != nil || built != nil {
+		t.Errorf("expected no mechanism and no error, got %v and %v", built, err)
+	}
+}
+
+func TestNewSASLMechanism_Unknown(t *testing.T) {
+	_, err := consumer.NewSASLMechanism("gssapi", "data-plane", "s3cret")
+
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
+
+func TestConnection_SharesSecurity(t *testing.T) {
+	mechanism, err := consumer.NewSASLMechanism(consumer.SASLPlain, "data-plane", "s3cret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	connection := consumer.Connection{
+		Brokers: []string{"kafka:9093"},
+		TLS:     &tls.Config{ServerName: "kafka", MinVersion: tls.VersionTLS12},
+		SASL:    mechanism,
+	}
+
+	dialer, transport := connection.Dialer(), connection.Transport()
+
+	if dialer.TLS != connection.TLS || transport.TLS != connection.TLS {
+		t.Error("expected the dialer and transport to use the connection's TLS config")
+	}
+	if dialer.SASLMechanism != mechanism || transport.SASL != mechanism {
+		t.Error("expected the dialer and transport to use the connection's SASL mechanism")
+	}
+}
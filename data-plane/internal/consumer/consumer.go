@@ -2,73 +2,312 @@ package consumer
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tracing"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ValidationPolicy decides what happens to events that fail validation.
+type ValidationPolicy string
+
+const (
+	// PolicyReject skips invalid events, sending them to the dead-letter
+	// topic when one is configured.
+	PolicyReject ValidationPolicy = "reject"
+	// PolicyWarn logs the violations and projects the event anyway.
+	PolicyWarn ValidationPolicy = "warn"
+)
+
+// ParseValidationPolicy parses a policy name.
+func ParseValidationPolicy(value string) (ValidationPolicy, error) {
+	switch policy := ValidationPolicy(value); policy {
+	case PolicyReject, PolicyWarn:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unknown validation policy %q (want %q or %q)", value, PolicyReject, PolicyWarn)
+}
+
+// DefaultRetryInterval is the wait between attempts of a failed write.
+const DefaultRetryInterval = time.Second
+
+// MessageReader fetches and commits messages; *kafka.Reader satisfies it.
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+}
+
+// MessageWriter publishes messages; *kafka.Writer satisfies it.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, messages ...kafka.Message) error
+}
+
 // Consumer reads events from Kafka and applies them to the projection.
 type Consumer struct {
-	reader     *kafka.Reader
+	reader     MessageReader
 	projection projection.SignalProjection
+	decoder    domain.Decoder
+	policy     ValidationPolicy
+	deadLetter MessageWriter
+	retry      time.Duration
+}
+
+// Option configures a Consumer.
+type Option func(*Consumer)
+
+// WithValidationPolicy sets how invalid events are handled. Defaults to
+// PolicyReject.
+func WithValidationPolicy(policy ValidationPolicy) Option {
+	return func(c *Consumer) {
+		c.policy = policy
+	}
+}
+
+// WithSchemas enables decoding Protobuf events framed in the Confluent wire
+// format against schemas. JSON events are always accepted.
+func WithSchemas(schemas domain.ProtoSchemas) Option {
+	return func(c *Consumer) {
+		c.decoder = domain.NewDecoder(schemas)
+	}
+}
+
+// WithDeadLetter sends rejected messages to writer before skipping them.
+func WithDeadLetter(writer MessageWriter) Option {
+	return func(c *Consumer) {
+		c.deadLetter = writer
+	}
+}
+
+// WithRetryInterval sets how long failed projection and dead-letter writes
+// wait before being retried. Defaults to DefaultRetryInterval.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(c *Consumer) {
+		c.retry = interval
+	}
 }
 
 // New creates a Consumer.
-func New(reader *kafka.Reader, proj projection.SignalProjection) Consumer {
-	return Consumer{reader: reader, projection: proj}
+func New(reader MessageReader, proj projection.SignalProjection, options ...Option) Consumer {
+	consumer := Consumer{
+		reader:     reader,
+		projection: proj,
+		decoder:    domain.NewDecoder(nil),
+		policy:     PolicyReject,
+		retry:      DefaultRetryInterval,
+	}
+	for _, option := range options {
+		option(&consumer)
+	}
+	return consumer
 }
 
-// Start begins the consume loop. Blocks until the context is cancelled.
-func (c Consumer) Start(ctx context.Context) error {
+// Start begins the consume loop. Cancelling ctx stops fetching, but the
+// message in flight is still applied and committed under inFlight, whose
+// cancellation abandons it for redelivery. Blocks until ctx is cancelled and
+// the message in flight is settled.
+func (c Consumer) Start(ctx, inFlight context.Context) error {
 	for ctx.Err() == nil {
-		c.processNext(ctx)
+		c.processNext(ctx, inFlight)
 	}
 	return ctx.Err()
 }
 
-func (c Consumer) processNext(ctx context.Context) {
+func (c Consumer) processNext(ctx, inFlight context.Context) {
 	message, err := c.reader.FetchMessage(ctx)
 	if err != nil {
-		log.Printf("error fetching message: %v", err)
+		if ctx.Err() == nil {
+			slog.ErrorContext(ctx, "error fetching message", "error", err)
+		}
 		return
 	}
 
-	event, err := domain.ParseSignalEvent(message.Value)
+	c.process(inFlight, message)
+	if inFlight.Err() != nil {
+		slog.WarnContext(ctx, "abandoned in-flight message at the shutdown deadline, leaving it for redelivery",
+			"topic", message.Topic, "partition", message.Partition, "offset", message.Offset)
+	}
+}
+
+func (c Consumer) process(ctx context.Context, message kafka.Message) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, &headerCarrier{headers: message.Headers})
+	ctx, span := tracer().Start(ctx, "process "+message.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", message.Topic),
+			attribute.Int("messaging.destination.partition.id", message.Partition),
+			attribute.Int64("messaging.kafka.offset", message.Offset),
+		),
+	)
+	defer span.End()
+
+	position := positionOf(message)
+	logger := slog.With("topic", message.Topic, "partition", message.Partition, "offset", message.Offset)
+	event, err := c.decode(ctx, message)
 	if err != nil {
-		log.Printf("skipping malformed message at offset %d: %v", message.Offset, err)
-		c.commit(ctx, message)
+		// Unsupported schema versions land here too; the error tells them apart.
+		logger.WarnContext(ctx, "rejecting undecodable message", "error", err)
+		c.reject(ctx, logger, message, position, err.Error())
 		return
 	}
+	logger = logger.With("signal_id", event.ID, "action", event.Action)
+	if event.TraceID != "" {
+		logger = logger.With("trace_id", event.TraceID)
+	}
+	if event.UnknownFields != "" {
+		countUnknownFields(event.UnknownFields)
+		logger.WarnContext(ctx, "signal has unknown fields", "fields", event.UnknownFields)
+	}
 
-	if !c.applyWithRetry(ctx, event) {
+	if violations := domain.Validate(event); len(violations) > 0 {
+		countViolations(violations)
+		reason := domain.JoinViolations(violations)
+		if c.policy == PolicyReject {
+			logger.WarnContext(ctx, "rejecting invalid signal", "violations", reason)
+			c.reject(ctx, logger, message, position, reason)
+			return
+		}
+		logger.WarnContext(ctx, "projecting invalid signal", "violations", reason)
+	}
+
+	if !c.applyWithRetry(ctx, logger, event, position) {
 		return
 	}
 
-	c.commit(ctx, message)
-	log.Printf("projected signal %s [%s]", event.ID, event.Action)
+	c.commit(ctx, logger, message)
+}
+
+// decode decodes a message and stamps the event with the trace it is
+// processed under, so the projected signal can be tied back to it.
+func (c Consumer) decode(ctx context.Context, message kafka.Message) (domain.SignalEvent, error) {
+	ctx, span := tracer().Start(ctx, "parse")
+	defer span.End()
+
+	event, err := c.decoder.Decode(message.Value, headersOf(message))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "decode failed")
+		return event, err
+	}
+	event.TraceID = tracing.TraceID(ctx)
+	span.SetAttributes(
+		attribute.String("signal.id", event.ID),
+		attribute.String("signal.action", string(event.Action)),
+	)
+	return event, nil
 }
 
 // applyWithRetry retries the projection until success or context cancellation.
+// Redelivered events the view already reflects count as success.
 // Returns true on success, false on context cancellation.
-func (c Consumer) applyWithRetry(ctx context.Context, event domain.SignalEvent) bool {
+func (c Consumer) applyWithRetry(ctx context.Context, logger *slog.Logger, event domain.SignalEvent, position domain.Position) bool {
+	ctx, span := tracer().Start(ctx, "apply", trace.WithAttributes(attribute.String("signal.id", event.ID)))
+	defer span.End()
+
+	for {
+		err := c.projection.ApplyAt(ctx, event, position)
+		if err == nil {
+			logger.InfoContext(ctx, "projected signal")
+			return true
+		}
+		if errors.Is(err, projection.ErrAlreadyApplied) {
+			logger.InfoContext(ctx, "skipping redelivered signal: already applied")
+			return true
+		}
+		span.RecordError(err)
+		logger.ErrorContext(ctx, "projection failed, retrying", "retry_in", c.retry, "error", err)
+		if !wait(ctx, c.retry) {
+			span.SetStatus(codes.Error, "cancelled before the projection succeeded")
+			return false
+		}
+	}
+}
+
+// reject sends a message that will not be applied to the dead-letter topic,
+// when one is configured, then skips it. The message is left uncommitted if
+// the context is cancelled before the dead-letter write succeeds.
+func (c Consumer) reject(ctx context.Context, logger *slog.Logger, message kafka.Message, position domain.Position, reason string) {
+	trace.SpanFromContext(ctx).SetStatus(codes.Error, reason)
+	if c.deadLetter != nil && !c.deadLetterWithRetry(ctx, logger, message, reason) {
+		return
+	}
+	c.skip(ctx, logger, message, position)
+}
+
+// deadLetterWithRetry retries the dead-letter write until success or context
+// cancellation. Returns true on success.
+func (c Consumer) deadLetterWithRetry(ctx context.Context, logger *slog.Logger, message kafka.Message, reason string) bool {
+	letter := deadLetterOf(message, reason)
 	for {
-		err := c.projection.Apply(ctx, event)
+		err := c.deadLetter.WriteMessages(ctx, letter)
 		if err == nil {
 			return true
 		}
-		log.Printf("projection failed, retrying in 1s: %v", err)
-		if !wait(ctx, time.Second) {
+		logger.ErrorContext(ctx, "dead-letter write failed, retrying", "retry_in", c.retry, "error", err)
+		if !wait(ctx, c.retry) {
 			return false
 		}
 	}
 }
 
-func (c Consumer) commit(ctx context.Context, message kafka.Message) {
+// skip records the position of a message that will not be applied, so the
+// view's position still moves past it, then commits it.
+func (c Consumer) skip(ctx context.Context, logger *slog.Logger, message kafka.Message, position domain.Position) {
+	if err := c.projection.Advance(ctx, position); err != nil {
+		logger.ErrorContext(ctx, "recording skipped position failed", "error", err)
+	}
+	c.commit(ctx, logger, message)
+}
+
+func (c Consumer) commit(ctx context.Context, logger *slog.Logger, message kafka.Message) {
+	ctx, span := tracer().Start(ctx, "commit")
+	defer span.End()
+
 	if err := c.reader.CommitMessages(ctx, message); err != nil {
-		log.Printf("offset commit failed: %v", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "commit failed")
+		logger.ErrorContext(ctx, "offset commit failed", "error", err)
+	}
+}
+
+// deadLetterOf copies a rejected message, recording why and where it came
+// from in its headers.
+func deadLetterOf(message kafka.Message, reason string) kafka.Message {
+	headers := append(slices.Clone(message.Headers),
+		kafka.Header{Key: "x-dead-letter-reason", Value: []byte(reason)},
+		kafka.Header{Key: "x-source-topic", Value: []byte(message.Topic)},
+		kafka.Header{Key: "x-source-partition", Value: []byte(strconv.Itoa(message.Partition))},
+		kafka.Header{Key: "x-source-offset", Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	)
+	return kafka.Message{Key: message.Key, Value: message.Value, Headers: headers}
+}
+
+// headersOf indexes a message's headers by lower-cased key.
+func headersOf(message kafka.Message) map[string]string {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[strings.ToLower(header.Key)] = string(header.Value)
+	}
+	return headers
+}
+
+func positionOf(message kafka.Message) domain.Position {
+	return domain.Position{
+		Topic:     message.Topic,
+		Partition: message.Partition,
+		Offset:    message.Offset,
 	}
 }
 
@@ -0,0 +1,190 @@
+package consumer_test
+
+import (
+	"context"
+	"expvar"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/consumer"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/redis/go-redis/v9"
+	"github.com/segmentio/kafka-go"
+)
+
+// invalidEvent fails validation only on its unknown priority.
+const invalidEvent = `{
+	"schema_version": 2,
+	"action": "created",
+	"id": "s1",
+	"data": {
+		"title": "Server Alert",
+		"priority": "Urgent",
+		"author": "otavio",
+		"created_at": "2026-02-23T15:00:00Z",
+		"updated_at": "2026-02-23T15:00:00Z"
+	}
+}`
+
+// fakeReader serves its messages in order, then cancels the consume loop.
+type fakeReader struct {
+	messages  []kafka.Message
+	committed []kafka.Message
+	stop      context.CancelFunc
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) == 0 {
+		r.stop()
+		return kafka.Message{}, ctx.Err()
+	}
+	message := r.messages[0]
+	r.messages = r.messages[1:]
+	return message, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, messages ...kafka.Message) error {
+	r.committed = append(r.committed, messages...)
+	return nil
+}
+
+type fakeWriter struct {
+	written []kafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, messages ...kafka.Message) error {
+	w.written = append(w.written, messages...)
+	return nil
+}
+
+func setupProjection(t *testing.T) projection.SignalProjection {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return projection.New(client)
+}
+
+// consume runs a consumer over messages until they are all processed.
+func consume(t *testing.T, proj projection.SignalProjection, messages []kafka.Message, options ...consumer.Option) *fakeReader {
+	t.Helper()
+	ctx, cancel := context.WithCancel(t.Context())
+	reader := &fakeReader{messages: messages, stop: cancel}
+
+	_ = consumer.New(reader, proj, options...).Start(ctx, t.Context())
+
+	return reader
+}
+
+func violationCount(code domain.ViolationCode) int64 {
+	counter, ok := expvar.Get("validation_violations").(*expvar.Map).Get(string(code)).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return counter.Value()
+}
+
+func TestConsumer_RejectDeadLettersAndCommits(t *testing.T) {
+	proj := setupProjection(t)
+	deadLetter := &fakeWriter{}
+	message := kafka.Message{Topic: "nexus.signals", Partition: 1, Offset: 7, Value: []byte(invalidEvent)}
+	before := violationCount(domain.ViolationUnknownPriority)
+
+	reader := consume(t, proj, []kafka.Message{message},
+		consumer.WithValidationPolicy(consumer.PolicyReject), consumer.WithDeadLetter(deadLetter))
+
+	if len(deadLetter.written) != 1 {
+		t.Fatalf("expected 1 dead letter, got %d", len(deadLetter.written))
+	}
+	headers := make(map[string]string)
+	for _, header := range deadLetter.written[0].Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	if headers["x-source-offset"] != "7" || headers["x-source-partition"] != "1" {
+		t.Errorf("expected source partition 1 and offset 7, got %v", headers)
+	}
+	if headers["x-dead-letter-reason"] == "" {
+		t.Error("expected a dead-letter reason header")
+	}
+	if len(reader.committed) != 1 || reader.committed[0].Offset != 7 {
+		t.Errorf("expected offset 7 committed, got %v", reader.committed)
+	}
+	if _, err := proj.FindByID(t.Context(), "s1"); err == nil {
+		t.Error("expected rejected signal not to be projected")
+	}
+	if got := violationCount(domain.ViolationUnknownPriority) - before; got != 1 {
+		t.Errorf("expected 1 unknown_priority violation counted, got %d", got)
+	}
+}
+
+func TestConsumer_WarnAppliesAndCounts(t *testing.T) {
+	proj := setupProjection(t)
+	deadLetter := &fakeWriter{}
+	message := kafka.Message{Topic: "nexus.signals", Partition: 0, Offset: 3, Value: []byte(invalidEvent)}
+	before := violationCount(domain.ViolationUnknownPriority)
+
+	reader := consume(t, proj, []kafka.Message{message},
+		consumer.WithValidationPolicy(consumer.PolicyWarn), consumer.WithDeadLetter(deadLetter))
+
+	signal, err := proj.FindByID(t.Context(), "s1")
+	if err != nil {
+		t.Fatalf("expected signal to be projected, got %v", err)
+	}
+	if signal.Priority != "Urgent" {
+		t.Errorf("expected priority %q, got %q", "Urgent", signal.Priority)
+	}
+	if len(deadLetter.written) != 0 {
+		t.Errorf("expected no dead letters, got %d", len(deadLetter.written))
+	}
+	if len(reader.committed) != 1 || reader.committed[0].Offset != 3 {
+		t.Errorf("expected offset 3 committed, got %v", reader.committed)
+	}
+	if got := violationCount(domain.ViolationUnknownPriority) - before; got != 1 {
+		t.Errorf("expected 1 unknown_priority violation counted, got %d", got)
+	}
+}
+
+func TestConsumer_MalformedMessageDeadLettered(t *testing.T) {
+	proj := setupProjection(t)
+	deadLetter := &fakeWriter{}
+	message := kafka.Message{Topic: "nexus.signals", Offset: 11, Value: []byte("not json")}
+
+	reader := consume(t, proj, []kafka.Message{message}, consumer.WithDeadLetter(deadLetter))
+
+	if len(deadLetter.written) != 1 || string(deadLetter.written[0].Value) != "not json" {
+		t.Errorf("expected the malformed message dead-lettered, got %v", deadLetter.written)
+	}
+	if len(reader.committed) != 1 || reader.committed[0].Offset != 11 {
+		t.Errorf("expected offset 11 committed, got %v", reader.committed)
+	}
+}
+
+func TestConsumer_CountsUnknownFields(t *testing.T) {
+	proj := setupProjection(t)
+	payload := `{"schema_version": 2, "action": "created", "id": "s1", "data": {
+		"title": "Server Alert", "priority": "High", "author": "otavio", "severity": 3,
+		"created_at": "2026-02-23T15:00:00Z", "updated_at": "2026-02-23T15:00:00Z"}}`
+	message := kafka.Message{Topic: "nexus.signals", Offset: 5, Value: []byte(payload)}
+	before := unknownFieldCount("data.severity")
+
+	reader := consume(t, proj, []kafka.Message{message}, consumer.WithValidationPolicy(consumer.PolicyReject))
+
+	if _, err := proj.FindByID(t.Context(), "s1"); err != nil {
+		t.Errorf("expected signal with unknown fields to be projected, got %v", err)
+	}
+	if len(reader.committed) != 1 {
+		t.Errorf("expected the message committed, got %v", reader.committed)
+	}
+	if got := unknownFieldCount("data.severity") - before; got != 1 {
+		t.Errorf("expected 1 unknown data.severity field counted, got %d", got)
+	}
+}
+
+func unknownFieldCount(field string) int64 {
+	counter, ok := expvar.Get("unknown_event_fields").(*expvar.Map).Get(field).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return counter.Value()
+}
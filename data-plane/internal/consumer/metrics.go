@@ -0,0 +1,28 @@
+package consumer
+
+import (
+	"expvar"
+	"strings"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+// violations counts validation failures per violation code. Published with
+// the other expvar variables at /debug/vars.
+var violations = expvar.NewMap("validation_violations")
+
+func countViolations(found []domain.Violation) {
+	for _, violation := range found {
+		violations.Add(string(violation.Code), 1)
+	}
+}
+
+// unknownFields counts events carrying payload keys the schema does not
+// define, per key, so producer drift shows up before it is relied upon.
+var unknownFields = expvar.NewMap("unknown_event_fields")
+
+func countUnknownFields(fields string) {
+	for field := range strings.SplitSeq(fields, ",") {
+		unknownFields.Add(field, 1)
+	}
+}
@@ -0,0 +1,64 @@
+package consumer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// Topic is the topic the control plane publishes signal events to.
+	Topic = "nexus.signals"
+	// DeadLetterTopic receives events rejected by the consumer.
+	DeadLetterTopic = "nexus.signals.dead-letter"
+	// GroupID is the consumer group shared by data-plane instances.
+	GroupID = "nexus-data-plane"
+)
+
+// Resume moves the consumer group to the positions recorded in the view, so
+// consumption restarts right after the last applied event. It must run
+// before the group's reader is created, while the group has no active
+// members. Returns the positions resumed from.
+func Resume(ctx context.Context, proj projection.SignalProjection, connection Connection, groupID string) ([]domain.Position, error) {
+	positions, err := proj.Positions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return positions, CommitGroupPositions(ctx, connection, groupID, positions)
+}
+
+// CommitGroupPositions commits offsets so the group resumes right after
+// each position. The group must have no active members.
+func CommitGroupPositions(ctx context.Context, connection Connection, groupID string, positions []domain.Position) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	topics := map[string][]kafka.OffsetCommit{}
+	for _, position := range positions {
+		topics[position.Topic] = append(topics[position.Topic], kafka.OffsetCommit{
+			Partition: position.Partition,
+			Offset:    position.Offset + 1,
+		})
+	}
+
+	client := &kafka.Client{Addr: kafka.TCP(connection.Brokers...), Transport: connection.Transport()}
+	response, err := client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      groupID,
+		GenerationID: -1,
+		Topics:       topics,
+	})
+	if err != nil {
+		return err
+	}
+	for topic, partitions := range response.Topics {
+		for _, partition := range partitions {
+			if partition.Error != nil {
+				return fmt.Errorf("%s partition %d: %w", topic, partition.Partition, partition.Error)
+			}
+		}
+	}
+	return nil
+}
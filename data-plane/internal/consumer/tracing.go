@@ -0,0 +1,48 @@
+package consumer
+
+import (
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/oragazz0/nexus-event-stream/data-plane/internal/consumer"
+
+// tracer is looked up per use so a provider installed after start-up applies.
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// headerCarrier adapts Kafka message headers to the propagation API.
+type headerCarrier struct {
+	headers []kafka.Header
+}
+
+func (c *headerCarrier) Get(key string) string {
+	for _, header := range c.headers {
+		if strings.EqualFold(header.Key, key) {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+func (c *headerCarrier) Set(key, value string) {
+	for index, header := range c.headers {
+		if strings.EqualFold(header.Key, key) {
+			c.headers[index].Value = []byte(value)
+			return
+		}
+	}
+	c.headers = append(c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c *headerCarrier) Keys() []string {
+	keys := make([]string, len(c.headers))
+	for index, header := range c.headers {
+		keys[index] = header.Key
+	}
+	return keys
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// cloudEventsSpecVersion is the only CloudEvents version accepted.
+	cloudEventsSpecVersion = "1.0"
+	// cloudEventsContentType marks a structured-mode message.
+	cloudEventsContentType = "application/cloudevents+json"
+	// cloudEventsHeaderPrefix prefixes attributes in binary mode, following
+	// the CloudEvents Kafka protocol binding.
+	cloudEventsHeaderPrefix = "ce_"
+)
+
+// ErrInvalidCloudEvent is returned for CloudEvents missing required
+// attributes or carrying data that is not JSON.
+var ErrInvalidCloudEvent = errors.New("invalid cloudevent")
+
+// cloudEvent holds the attributes of a CloudEvent in either content mode.
+type cloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	Type            string          `json:"type"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Subject         string          `json:"subject"`
+	Time            string          `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data"`
+	DataBase64      []byte          `json:"data_base64"`
+}
+
+// cloudEventData is the signal carried as a CloudEvent's data. The signal ID
+// is taken from the subject attribute, falling back to data.id.
+type cloudEventData struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Content    string `json:"content"`
+	Priority   string `json:"priority"`
+	Author     string `json:"author"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+	Visibility string `json:"visibility"`
+}
+
+// DecodeSignalEvent decodes a Kafka message value in any supported format,
+// selected per message: a CloudEvent in binary content mode (ce_* headers),
+// a CloudEvent in structured content mode, or the native versioned envelope.
+// Header keys are expected in lower case.
+func DecodeSignalEvent(value []byte, headers map[string]string) (SignalEvent, error) {
+	if _, ok := headers[cloudEventsHeaderPrefix+"specversion"]; ok {
+		return binaryCloudEvent(value, headers).signalEvent()
+	}
+	if isStructuredCloudEvent(value, headers) {
+		var event cloudEvent
+		if err := json.Unmarshal(value, &event); err != nil {
+			return SignalEvent{}, err
+		}
+		return event.signalEvent()
+	}
+	return ParseSignalEvent(value)
+}
+
+func isStructuredCloudEvent(value []byte, headers map[string]string) bool {
+	if strings.HasPrefix(headers["content-type"], cloudEventsContentType) {
+		return true
+	}
+	var probe struct {
+		SpecVersion *string `json:"specversion"`
+	}
+	return json.Unmarshal(value, &probe) == nil && probe.SpecVersion != nil
+}
+
+func binaryCloudEvent(value []byte, headers map[string]string) cloudEvent {
+	attribute := func(name string) string {
+		return headers[cloudEventsHeaderPrefix+name]
+	}
+	return cloudEvent{
+		SpecVersion:     attribute("specversion"),
+		Type:            attribute("type"),
+		ID:              attribute("id"),
+		Source:          attribute("source"),
+		Subject:         attribute("subject"),
+		Time:            attribute("time"),
+		DataContentType: headers["content-type"],
+		Data:            value,
+	}
+}
+
+// signalEvent maps the CloudEvent onto a SignalEvent: the action is the last
+// dot-separated segment of type (e.g. "com.example.signal.created").
+func (e cloudEvent) signalEvent() (SignalEvent, error) {
+	if e.SpecVersion != cloudEventsSpecVersion {
+		return SignalEvent{}, fmt.Errorf("%w: specversion %q", ErrInvalidCloudEvent, e.SpecVersion)
+	}
+	var missing []string
+	for _, attribute := range [][2]string{{"id", e.ID}, {"source", e.Source}, {"type", e.Type}} {
+		if attribute[1] == "" {
+			missing = append(missing, attribute[0])
+		}
+	}
+	if len(missing) > 0 {
+		return SignalEvent{}, fmt.Errorf("%w: missing %s", ErrInvalidCloudEvent, strings.Join(missing, ", "))
+	}
+	if e.Time != "" {
+		if _, err := time.Parse(time.RFC3339, e.Time); err != nil {
+			return SignalEvent{}, fmt.Errorf("%w: time %q", ErrInvalidCloudEvent, e.Time)
+		}
+	}
+
+	data, err := e.data()
+	if err != nil {
+		return SignalEvent{}, err
+	}
+	id := e.Subject
+	if id == "" {
+		id = data.ID
+	}
+	return SignalEvent{
+		Action:     Action(e.Type[strings.LastIndex(e.Type, ".")+1:]),
+		ID:         id,
+		Title:      data.Title,
+		Content:    data.Content,
+		Priority:   data.Priority,
+		Author:     data.Author,
+		CreatedAt:  data.CreatedAt,
+		UpdatedAt:  data.UpdatedAt,
+		Visibility: data.Visibility,
+		EventID:    e.ID,
+		Source:     e.Source,
+		OccurredAt: e.Time,
+	}, nil
+}
+
+func (e cloudEvent) data() (cloudEventData, error) {
+	var data cloudEventData
+	if !isJSONContentType(e.DataContentType) {
+		return data, fmt.Errorf("%w: datacontenttype %q", ErrInvalidCloudEvent, e.DataContentType)
+	}
+	raw := []byte(e.Data)
+	if len(e.DataBase64) > 0 {
+		raw = e.DataBase64
+	}
+	if len(raw) == 0 || string(raw) == "null" {
+		return data, nil
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return data, fmt.Errorf("%w: data: %v", ErrInvalidCloudEvent, err)
+	}
+	return data, nil
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(mediaType)
+	return mediaType == "" || mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
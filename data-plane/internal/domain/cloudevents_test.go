@@ -0,0 +1,128 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func cloudEventSignal() domain.SignalEvent {
+	return domain.SignalEvent{
+		Action:     domain.ActionCreated,
+		ID:         "abc-123",
+		Title:      "Server Alert",
+		Content:    "CPU at 95%",
+		Priority:   "High",
+		Author:     "otavio",
+		CreatedAt:  "2026-02-23T15:00:00-03:00",
+		UpdatedAt:  "2026-02-23T15:00:00-03:00",
+		EventID:    "evt-001",
+		Source:     "https://alerts.example.com",
+		OccurredAt: "2026-02-23T18:00:01Z",
+	}
+}
+
+func binaryHeaders() map[string]string {
+	return map[string]string{
+		"ce_specversion": "1.0",
+		"ce_type":        "com.example.signal.created",
+		"ce_id":          "evt-001",
+		"ce_source":      "https://alerts.example.com",
+		"ce_time":        "2026-02-23T18:00:01Z",
+		"content-type":   "application/json",
+	}
+}
+
+func TestDecodeSignalEvent_StructuredMode(t *testing.T) {
+	event, err := domain.DecodeSignalEvent(readFixture(t, "cloudevents_structured.json"), nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event != cloudEventSignal() {
+		t.Errorf("expected %+v, got %+v", cloudEventSignal(), event)
+	}
+}
+
+func TestDecodeSignalEvent_StructuredModeBase64Data(t *testing.T) {
+	payload := []byte(`{
+		"specversion": "1.0",
+		"type": "com.example.signal.deleted",
+		"id": "evt-002",
+		"source": "https://alerts.example.com",
+		"data_base64": "eyJpZCI6ICJhYmMtMTIzIn0="
+	}`)
+	headers := map[string]string{"content-type": "application/cloudevents+json; charset=utf-8"}
+
+	event, err := domain.DecodeSignalEvent(payload, headers)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Action != domain.ActionDeleted || event.ID != "abc-123" {
+		t.Errorf("expected deleted event for abc-123, got %+v", event)
+	}
+}
+
+func TestDecodeSignalEvent_BinaryMode(t *testing.T) {
+	event, err := domain.DecodeSignalEvent(readFixture(t, "cloudevents_binary_data.json"), binaryHeaders())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event != cloudEventSignal() {
+		t.Errorf("expected %+v, got %+v", cloudEventSignal(), event)
+	}
+}
+
+func TestDecodeSignalEvent_BinaryModeSubjectWins(t *testing.T) {
+	headers := binaryHeaders()
+	headers["ce_subject"] = "from-subject"
+
+	event, err := domain.DecodeSignalEvent(readFixture(t, "cloudevents_binary_data.json"), headers)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.ID != "from-subject" {
+		t.Errorf("expected id from subject, got %q", event.ID)
+	}
+}
+
+func TestDecodeSignalEvent_NativeEnvelope(t *testing.T) {
+	event, err := domain.DecodeSignalEvent(readFixture(t, "v2_created.json"), map[string]string{"content-type": "application/json"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.ID != "abc-123" || event.EventID != "" {
+		t.Errorf("expected native event without CloudEvents attributes, got %+v", event)
+	}
+}
+
+func TestDecodeSignalEvent_InvalidCloudEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(map[string]string)
+		payload string
+	}{
+		{"missing source", func(headers map[string]string) { delete(headers, "ce_source") }, `{}`},
+		{"unsupported specversion", func(headers map[string]string) { headers["ce_specversion"] = "0.3" }, `{}`},
+		{"invalid time", func(headers map[string]string) { headers["ce_time"] = "yesterday" }, `{}`},
+		{"non-JSON data", func(headers map[string]string) { headers["content-type"] = "application/xml" }, `<signal/>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers := binaryHeaders()
+			test.mutate(headers)
+
+			_, err := domain.DecodeSignalEvent([]byte(test.payload), headers)
+
+			if !errors.Is(err, domain.ErrInvalidCloudEvent) {
+				t.Errorf("expected ErrInvalidCloudEvent, got %v", err)
+			}
+		})
+	}
+}
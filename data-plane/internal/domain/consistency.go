@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned for consistency tokens in neither supported form.
+var ErrInvalidToken = errors.New("invalid consistency token")
+
+// tokenDeleted stands for a deletion in place of updated_at in a signal
+// token.
+const tokenDeleted = "deleted"
+
+// ConsistencyToken is a point a reader needs the projection to have reached:
+// either a partition offset or a write to one signal, its new updated_at or
+// its deletion.
+type ConsistencyToken struct {
+	Partition int
+	Offset    int64
+	SignalID  string
+	UpdatedAt time.Time
+	Deleted   bool
+}
+
+// IsSignal reports whether the token is a signal write rather than a
+// partition offset.
+func (t ConsistencyToken) IsSignal() bool {
+	return t.SignalID != ""
+}
+
+// ParseConsistencyToken accepts either "<partition>:<offset>", as returned
+// by the producer's delivery report, or "<signal-id>@<updated_at>" with an
+// RFC 3339 updated_at, or "<signal-id>@deleted" after a delete.
+func ParseConsistencyToken(value string) (ConsistencyToken, error) {
+	if at := strings.LastIndexByte(value, '@'); at >= 0 {
+		return parseSignalToken(value[:at], value[at+1:])
+	}
+
+	partitionText, offsetText, found := strings.Cut(value, ":")
+	if !found {
+		return ConsistencyToken{}, ErrInvalidToken
+	}
+	partition, err := strconv.Atoi(partitionText)
+	if err != nil || partition < 0 {
+		return ConsistencyToken{}, ErrInvalidToken
+	}
+	offset, err := strconv.ParseInt(offsetText, 10, 64)
+	if err != nil || offset < 0 {
+		return ConsistencyToken{}, ErrInvalidToken
+	}
+	return ConsistencyToken{Partition: partition, Offset: offset}, nil
+}
+
+func parseSignalToken(id, write string) (ConsistencyToken, error) {
+	if id == "" {
+		return ConsistencyToken{}, ErrInvalidToken
+	}
+	if write == tokenDeleted {
+		return ConsistencyToken{SignalID: id, Deleted: true}, nil
+	}
+	updatedAt, err := time.Parse(time.RFC3339Nano, write)
+	if err != nil {
+		return ConsistencyToken{}, ErrInvalidToken
+	}
+	return ConsistencyToken{SignalID: id, UpdatedAt: updatedAt}, nil
+}
@@ -0,0 +1,61 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func TestParseConsistencyToken_Offset(t *testing.T) {
+	token, err := domain.ParseConsistencyToken("2:1048")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.IsSignal() {
+		t.Error("expected an offset token")
+	}
+	if token.Partition != 2 || token.Offset != 1048 {
+		t.Errorf("expected partition 2 offset 1048, got %d:%d", token.Partition, token.Offset)
+	}
+}
+
+func TestParseConsistencyToken_SignalUpdate(t *testing.T) {
+	token, err := domain.ParseConsistencyToken("abc-123@2026-02-23T15:05:00.123456-03:00")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !token.IsSignal() || token.SignalID != "abc-123" || token.Deleted {
+		t.Fatalf("expected an update token for abc-123, got %+v", token)
+	}
+	expected := time.Date(2026, 2, 23, 18, 5, 0, 123456000, time.UTC)
+	if !token.UpdatedAt.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, token.UpdatedAt)
+	}
+}
+
+func TestParseConsistencyToken_SignalDeleted(t *testing.T) {
+	token, err := domain.ParseConsistencyToken("abc-123@deleted")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !token.IsSignal() || token.SignalID != "abc-123" || !token.Deleted {
+		t.Errorf("expected a delete token for abc-123, got %+v", token)
+	}
+}
+
+func TestParseConsistencyToken_Invalid(t *testing.T) {
+	for _, value := range []string{"", "42", "a:1", "0:-1", "-1:5", "yesterday", "2026-02-23T15:05:00Z", "@2026-02-23T15:05:00Z", "abc-123@yesterday"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := domain.ParseConsistencyToken(value)
+
+			if !errors.Is(err, domain.ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+// APIVersion is the version of the read API served under /v1.
+const APIVersion = "v1"
+
+// Envelope is the body of every v1 read API response: the resource itself
+// in Data, which part of a list it holds in Page, and details about the
+// response in Meta. New metadata goes in Page or Meta, leaving Data as is.
+type Envelope[T any] struct {
+	Data T     `json:"data"`
+	Page *Page `json:"page,omitempty"`
+	Meta Meta  `json:"meta"`
+}
+
+// Page describes the part of a list a response holds.
+type Page struct {
+	Count int `json:"count"`
+	// Limit caps Count when the list is truncated. Zero means the list is
+	// complete.
+	Limit int `json:"limit,omitempty"`
+}
+
+// Meta describes a response.
+type Meta struct {
+	Version string `json:"version"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// signalFields are the fields of a Signal by their JSON names, which are
+// also their names in the projection's hashes.
+var signalFields = []string{
+	"id", "title", "content", "priority", "author", "created_at", "updated_at", "visibility", "trace_id",
+}
+
+// SignalFields returns the names of every Signal field.
+func SignalFields() []string {
+	return slices.Clone(signalFields)
+}
+
+// ParseFields parses a comma-separated list of Signal field names, as sent
+// in ?fields=id,title,priority, dropping duplicates. An empty list selects
+// every field and yields nil.
+func ParseFields(value string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" || slices.Contains(fields, field) {
+			continue
+		}
+		if !slices.Contains(signalFields, field) {
+			return nil, fmt.Errorf("unknown field %q, expected any of %s", field, strings.Join(signalFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// Select returns the given fields of the signal by their JSON names.
+func (s Signal) Select(fields []string) map[string]string {
+	values := map[string]string{
+		"id":         s.ID,
+		"title":      s.Title,
+		"content":    s.Content,
+		"priority":   s.Priority,
+		"author":     s.Author,
+		"created_at": s.CreatedAt,
+		"updated_at": s.UpdatedAt,
+		"visibility": s.Visibility,
+		"trace_id":   s.TraceID,
+	}
+	selected := make(map[string]string, len(fields))
+	for _, field := range fields {
+		selected[field] = values[field]
+	}
+	return selected
+}
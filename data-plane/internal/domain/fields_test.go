@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected []string
+	}{
+		"empty":            {"", nil},
+		"only separators":  {" , ,", nil},
+		"trims":            {" id , title ", []string{"id", "title"}},
+		"drops duplicates": {"priority,id,priority", []string{"priority", "id"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fields, err := domain.ParseFields(test.value)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(fields, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, fields)
+			}
+		})
+	}
+}
+
+func TestParseFields_UnknownField(t *testing.T) {
+	_, err := domain.ParseFields("id,secret")
+
+	if err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+}
+
+func TestSelect(t *testing.T) {
+	signal := domain.Signal{ID: "abc-123", Title: "Server Alert", Priority: "High", Author: "otavio"}
+
+	selected := signal.Select([]string{"title", "priority"})
+
+	expected := map[string]string{"title": "Server Alert", "priority": "High"}
+	if len(selected) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, selected)
+	}
+	for field, value := range expected {
+		if selected[field] != value {
+			t.Errorf("expected %s %q, got %q", field, value, selected[field])
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package domain
+
+// Position identifies the last event applied from a topic partition.
+type Position struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
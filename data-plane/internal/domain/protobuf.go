@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// confluentMagicByte starts every payload framed in the Confluent wire
+// format: magic byte, 4-byte big-endian schema ID, message indexes, message.
+const confluentMagicByte = 0x00
+
+var (
+	// ErrNoSchemaRegistry is returned for Protobuf payloads when the decoder
+	// has no schemas to resolve them against.
+	ErrNoSchemaRegistry = errors.New("protobuf event received without a schema registry")
+	// ErrInvalidWireFormat is returned for truncated or corrupt framing.
+	ErrInvalidWireFormat = errors.New("invalid confluent wire format")
+)
+
+// ProtoSchemas resolves the message a Confluent-framed payload was written
+// with: the schema ID plus the message indexes path.
+type ProtoSchemas interface {
+	MessageFields(schemaID int, indexes []int) (map[int32]string, error)
+}
+
+// Decoder decodes Kafka messages in every supported format: Protobuf in
+// the Confluent wire format when schemas are configured, and the JSON
+// formats handled by DecodeSignalEvent.
+type Decoder struct {
+	schemas ProtoSchemas
+}
+
+// NewDecoder creates a Decoder. Pass nil schemas to accept JSON only.
+func NewDecoder(schemas ProtoSchemas) Decoder {
+	return Decoder{schemas: schemas}
+}
+
+// Decode decodes a message value, telling Protobuf from JSON by the magic
+// byte, which never starts a JSON document.
+func (d Decoder) Decode(value []byte, headers map[string]string) (SignalEvent, error) {
+	if len(value) == 0 || value[0] != confluentMagicByte {
+		return DecodeSignalEvent(value, headers)
+	}
+	if d.schemas == nil {
+		return SignalEvent{}, ErrNoSchemaRegistry
+	}
+	schemaID, indexes, message, err := splitWireFormat(value)
+	if err != nil {
+		return SignalEvent{}, err
+	}
+	names, err := d.schemas.MessageFields(schemaID, indexes)
+	if err != nil {
+		return SignalEvent{}, err
+	}
+	return unmarshalProto(message, names)
+}
+
+func splitWireFormat(value []byte) (int, []int, []byte, error) {
+	if len(value) < 5 {
+		return 0, nil, nil, fmt.Errorf("%w: %d byte payload", ErrInvalidWireFormat, len(value))
+	}
+	schemaID := int(binary.BigEndian.Uint32(value[1:5]))
+	rest := value[5:]
+
+	count, n := consumeZigZag(rest)
+	rest = rest[max(n, 0):]
+	// Every index takes at least a byte, so a count beyond what is left is
+	// malformed, and must not size an allocation.
+	if n < 0 || count < 0 || count > int64(len(rest)) {
+		return 0, nil, nil, fmt.Errorf("%w: message indexes", ErrInvalidWireFormat)
+	}
+	// A single zero stands for [0], the first message in the schema.
+	indexes := []int{0}
+	if count > 0 {
+		indexes = make([]int, count)
+		for position := range indexes {
+			index, n := consumeZigZag(rest)
+			if n < 0 {
+				return 0, nil, nil, fmt.Errorf("%w: message indexes", ErrInvalidWireFormat)
+			}
+			indexes[position] = int(index)
+			rest = rest[n:]
+		}
+	}
+	return schemaID, indexes, rest, nil
+}
+
+func consumeZigZag(data []byte) (int64, int) {
+	value, n := protowire.ConsumeVarint(data)
+	return protowire.DecodeZigZag(value), n
+}
+
+// unmarshalProto reads the string fields of a Protobuf message by name.
+// Fields missing from names are skipped, like unknown JSON fields.
+func unmarshalProto(message []byte, names map[int32]string) (SignalEvent, error) {
+	fields := map[string]string{}
+	for len(message) > 0 {
+		number, wireType, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return SignalEvent{}, protowire.ParseError(n)
+		}
+		message = message[n:]
+
+		name, known := names[int32(number)]
+		if known && wireType == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(message)
+			if n < 0 {
+				return SignalEvent{}, protowire.ParseError(n)
+			}
+			fields[name] = string(value)
+			message = message[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(number, wireType, message)
+		if n < 0 {
+			return SignalEvent{}, protowire.ParseError(n)
+		}
+		message = message[n:]
+	}
+	return SignalEvent{
+		Action:     Action(fields["action"]),
+		ID:         fields["id"],
+		Title:      fields["title"],
+		Content:    fields["content"],
+		Priority:   fields["priority"],
+		Author:     fields["author"],
+		CreatedAt:  fields["created_at"],
+		UpdatedAt:  fields["updated_at"],
+		Visibility: fields["visibility"],
+	}, nil
+}
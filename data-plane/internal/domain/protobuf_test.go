@@ -0,0 +1,153 @@
+package domain_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+type stubSchemas map[int]map[int32]string
+
+func (s stubSchemas) MessageFields(schemaID int, indexes []int) (map[int32]string, error) {
+	fields, ok := s[schemaID]
+	if !ok {
+		return nil, errors.New("schema not found")
+	}
+	return fields, nil
+}
+
+var schemas = stubSchemas{
+	1: {1: "action", 2: "id", 5: "priority", 7: "created_at"},
+	// Version 2 renumbered the fields; decoding follows the schema.
+	2: {10: "action", 11: "id", 12: "priority", 13: "created_at"},
+}
+
+// frame encodes fields in the Confluent wire format with the [0] message
+// indexes shorthand.
+func frame(schemaID uint32, fields map[protowire.Number]string) []byte {
+	payload := []byte{0}
+	payload = binary.BigEndian.AppendUint32(payload, schemaID)
+	payload = append(payload, 0)
+	for number, value := range fields {
+		payload = protowire.AppendTag(payload, number, protowire.BytesType)
+		payload = protowire.AppendString(payload, value)
+	}
+	return payload
+}
+
+func TestDecoder_Protobuf(t *testing.T) {
+	decoder := domain.NewDecoder(schemas)
+	payload := frame(1, map[protowire.Number]string{
+		1: "created", 2: "abc-123", 5: "High", 7: "2026-02-23T15:00:00Z",
+	})
+	// Unknown fields are skipped.
+	payload = protowire.AppendTag(payload, 99, protowire.VarintType)
+	payload = protowire.AppendVarint(payload, 42)
+
+	event, err := decoder.Decode(payload, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := domain.SignalEvent{
+		Action:    domain.ActionCreated,
+		ID:        "abc-123",
+		Priority:  "High",
+		CreatedAt: "2026-02-23T15:00:00Z",
+	}
+	if event != expected {
+		t.Errorf("expected %+v, got %+v", expected, event)
+	}
+}
+
+func TestDecoder_ProtobufFollowsSchemaID(t *testing.T) {
+	decoder := domain.NewDecoder(schemas)
+	payload := frame(2, map[protowire.Number]string{10: "deleted", 11: "abc-123"})
+
+	event, err := decoder.Decode(payload, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Action != domain.ActionDeleted || event.ID != "abc-123" {
+		t.Errorf("expected deleted event for abc-123, got %+v", event)
+	}
+}
+
+func TestDecoder_ExplicitMessageIndexes(t *testing.T) {
+	decoder := domain.NewDecoder(schemas)
+	payload := []byte{0}
+	payload = binary.BigEndian.AppendUint32(payload, 1)
+	payload = protowire.AppendVarint(payload, protowire.EncodeZigZag(1))
+	payload = protowire.AppendVarint(payload, protowire.EncodeZigZag(0))
+	payload = protowire.AppendTag(payload, 2, protowire.BytesType)
+	payload = protowire.AppendString(payload, "abc-123")
+
+	event, err := decoder.Decode(payload, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.ID != "abc-123" {
+		t.Errorf("expected id %q, got %q", "abc-123", event.ID)
+	}
+}
+
+func TestDecoder_AcceptsJSON(t *testing.T) {
+	decoder := domain.NewDecoder(schemas)
+
+	event, err := decoder.Decode(readFixture(t, "v2_created.json"), nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.ID != "abc-123" {
+		t.Errorf("expected id %q, got %q", "abc-123", event.ID)
+	}
+}
+
+func TestDecoder_ProtobufWithoutSchemas(t *testing.T) {
+	decoder := domain.NewDecoder(nil)
+
+	_, err := decoder.Decode(frame(1, nil), nil)
+
+	if !errors.Is(err, domain.ErrNoSchemaRegistry) {
+		t.Errorf("expected ErrNoSchemaRegistry, got %v", err)
+	}
+}
+
+func TestDecoder_TruncatedFrame(t *testing.T) {
+	decoder := domain.NewDecoder(schemas)
+
+	_, err := decoder.Decode([]byte{0, 0, 0}, nil)
+
+	if !errors.Is(err, domain.ErrInvalidWireFormat) {
+		t.Errorf("expected ErrInvalidWireFormat, got %v", err)
+	}
+}
+
+func TestDecoder_HugeIndexCount(t *testing.T) {
+	decoder := domain.NewDecoder(schemas)
+	payload := []byte{0}
+	payload = binary.BigEndian.AppendUint32(payload, 1)
+	payload = protowire.AppendVarint(payload, protowire.EncodeZigZag(1<<40))
+
+	_, err := decoder.Decode(payload, nil)
+
+	if !errors.Is(err, domain.ErrInvalidWireFormat) {
+		t.Errorf("expected ErrInvalidWireFormat, got %v", err)
+	}
+}
+
+func TestDecoder_UnknownSchema(t *testing.T) {
+	decoder := domain.NewDecoder(schemas)
+
+	_, err := decoder.Decode(frame(3, nil), nil)
+
+	if err == nil {
+		t.Fatal("expected error for an unknown schema ID, got nil")
+	}
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CurrentSchemaVersion is the event envelope layout SignalEvent is decoded from.
+//
+//	v1: flat payload without schema_version: {"action", "id", "title", ...}
+//	v2: {"schema_version": 2, "action", "id", "data": {"title", ...}}
+//
+// The optional data.visibility was added to v2 without a version bump, and
+// to the Protobuf schema 1 as field 9: events without it decode as public
+// signals.
+const CurrentSchemaVersion = 2
+
+// legacySchemaVersion is assumed for payloads without a schema_version field.
+const legacySchemaVersion = 1
+
+// ErrUnsupportedSchemaVersion is returned for envelopes newer than
+// CurrentSchemaVersion or with no upcaster path to it.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported event schema version")
+
+// envelope is a decoded payload whose fields are kept raw so upcasters can
+// reshape it without knowing every field.
+type envelope map[string]json.RawMessage
+
+// Upcaster converts an envelope from one schema version to the next.
+type Upcaster func(envelope) (envelope, error)
+
+// upcasters maps each version to the function converting it to version+1.
+var upcasters = map[int]Upcaster{
+	1: upcastV1ToV2,
+}
+
+// signalData carries the signal fields nested under "data" in v2.
+var signalData = []string{"title", "content", "priority", "author", "created_at", "updated_at", "visibility"}
+
+// envelopeFields are the top-level keys of a v2 envelope.
+var envelopeFields = []string{"schema_version", "action", "id", "data"}
+
+type eventV2 struct {
+	Action Action `json:"action"`
+	ID     string `json:"id"`
+	Data   struct {
+		Title      string `json:"title"`
+		Content    string `json:"content"`
+		Priority   string `json:"priority"`
+		Author     string `json:"author"`
+		CreatedAt  string `json:"created_at"`
+		UpdatedAt  string `json:"updated_at"`
+		Visibility string `json:"visibility"`
+	} `json:"data"`
+}
+
+// ParseSignalEvent deserializes a JSON payload of any supported schema
+// version into a SignalEvent, upcasting older envelopes step by step. Keys
+// the schema does not define are not an error: they are listed in the
+// event's UnknownFields so producer drift can be reported.
+func ParseSignalEvent(data []byte) (SignalEvent, error) {
+	var payload envelope
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return SignalEvent{}, err
+	}
+	upcasted, err := upcast(payload)
+	if err != nil {
+		return SignalEvent{}, err
+	}
+	return decodeV2(upcasted)
+}
+
+func upcast(payload envelope) (envelope, error) {
+	version, err := schemaVersion(payload)
+	if err != nil {
+		return nil, err
+	}
+	for version < CurrentSchemaVersion {
+		upcaster, ok := upcasters[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: no upcaster from version %d", ErrUnsupportedSchemaVersion, version)
+		}
+		payload, err = upcaster(payload)
+		if err != nil {
+			return nil, fmt.Errorf("upcasting from version %d: %w", version, err)
+		}
+		version++
+	}
+	return payload, nil
+}
+
+func schemaVersion(payload envelope) (int, error) {
+	raw, ok := payload["schema_version"]
+	if !ok {
+		return legacySchemaVersion, nil
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return 0, fmt.Errorf("invalid schema_version: %w", err)
+	}
+	if version < legacySchemaVersion || version > CurrentSchemaVersion {
+		return 0, fmt.Errorf("%w: %d", ErrUnsupportedSchemaVersion, version)
+	}
+	return version, nil
+}
+
+// upcastV1ToV2 moves the flat signal fields under "data".
+func upcastV1ToV2(payload envelope) (envelope, error) {
+	data := envelope{}
+	for _, field := range signalData {
+		if value, ok := payload[field]; ok {
+			data[field] = value
+			delete(payload, field)
+		}
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	payload["data"] = encoded
+	payload["schema_version"] = json.RawMessage("2")
+	return payload, nil
+}
+
+func decodeV2(payload envelope) (SignalEvent, error) {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return SignalEvent{}, err
+	}
+	var event eventV2
+	if err := json.Unmarshal(encoded, &event); err != nil {
+		return SignalEvent{}, err
+	}
+	unknown, err := unknownFields(payload)
+	if err != nil {
+		return SignalEvent{}, err
+	}
+	return SignalEvent{
+		Action:        event.Action,
+		ID:            event.ID,
+		Title:         event.Data.Title,
+		Content:       event.Data.Content,
+		Priority:      event.Data.Priority,
+		Author:        event.Data.Author,
+		CreatedAt:     event.Data.CreatedAt,
+		UpdatedAt:     event.Data.UpdatedAt,
+		Visibility:    event.Data.Visibility,
+		UnknownFields: unknown,
+	}, nil
+}
+
+// unknownFields lists the keys of a v2 envelope outside envelopeFields and
+// signalData, sorted and comma-separated, with data keys prefixed "data.".
+func unknownFields(payload envelope) (string, error) {
+	var unknown []string
+	for field := range payload {
+		if !slices.Contains(envelopeFields, field) {
+			unknown = append(unknown, field)
+		}
+	}
+	if raw, ok := payload["data"]; ok && string(raw) != "null" {
+		var data envelope
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return "", err
+		}
+		for field := range data {
+			if !slices.Contains(signalData, field) {
+				unknown = append(unknown, "data."+field)
+			}
+		}
+	}
+	slices.Sort(unknown)
+	return strings.Join(unknown, ","), nil
+}
@@ -0,0 +1,100 @@
+package domain_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	payload, err := os.ReadFile(filepath.Join("testdata", "events", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture %s: %v", name, err)
+	}
+	return payload
+}
+
+func TestParseSignalEvent_SupportedVersions(t *testing.T) {
+	created := domain.SignalEvent{
+		Action:    domain.ActionCreated,
+		ID:        "abc-123",
+		Title:     "Server Alert",
+		Content:   "CPU at 95%",
+		Priority:  "High",
+		Author:    "otavio",
+		CreatedAt: "2026-02-23T15:00:00-03:00",
+		UpdatedAt: "2026-02-23T15:00:00-03:00",
+	}
+	deleted := domain.SignalEvent{Action: domain.ActionDeleted, ID: "abc-123"}
+	tests := []struct {
+		fixture  string
+		expected domain.SignalEvent
+	}{
+		{"v1_created.json", created},
+		{"v1_deleted.json", deleted},
+		{"v2_created.json", created},
+		{"v2_deleted.json", deleted},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			event, err := domain.ParseSignalEvent(readFixture(t, test.fixture))
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if event != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, event)
+			}
+		})
+	}
+}
+
+func TestParseSignalEvent_RejectsFutureVersion(t *testing.T) {
+	_, err := domain.ParseSignalEvent(readFixture(t, "v3_created.json"))
+
+	if !errors.Is(err, domain.ErrUnsupportedSchemaVersion) {
+		t.Errorf("expected ErrUnsupportedSchemaVersion, got %v", err)
+	}
+}
+
+func TestParseSignalEvent_InvalidSchemaVersion(t *testing.T) {
+	tests := []string{
+		`{"schema_version": 0, "action": "deleted", "id": "abc-123"}`,
+		`{"schema_version": "2", "action": "deleted", "id": "abc-123"}`,
+	}
+
+	for _, payload := range tests {
+		_, err := domain.ParseSignalEvent([]byte(payload))
+
+		if err == nil {
+			t.Errorf("expected error for %s, got nil", payload)
+		}
+	}
+}
+
+func TestParseSignalEvent_ListsUnknownFields(t *testing.T) {
+	tests := map[string]string{
+		`{"schema_version": 2, "action": "created", "id": "abc-123", "data": {"title": "Alert"}}`:                               "",
+		`{"schema_version": 2, "action": "created", "id": "abc-123", "source": "x", "data": {"title": "Alert", "severity": 3}}`: "data.severity,source",
+		`{"action": "created", "id": "abc-123", "title": "Alert", "severity": 3}`:                                               "severity",
+	}
+
+	for payload, expected := range tests {
+		event, err := domain.ParseSignalEvent([]byte(payload))
+
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", payload, err)
+		}
+		if event.Title != "Alert" {
+			t.Errorf("expected known fields decoded for %s, got title %q", payload, event.Title)
+		}
+		if event.UnknownFields != expected {
+			t.Errorf("expected unknown fields %q for %s, got %q", expected, payload, event.UnknownFields)
+		}
+	}
+}
@@ -1,6 +1,6 @@
 package domain
 
-import "encoding/json"
+import "slices"
 
 // Action represents the CRUD operation that triggered the event.
 type Action string
@@ -11,23 +11,88 @@ const (
 	ActionDeleted Action = "deleted"
 )
 
+// Priorities returns the priority levels from lowest to highest. It is the
+// single list validation, the projection's priority index and retention
+// derive theirs from.
+func Priorities() []string {
+	return []string{"Low", "Medium", "High"}
+}
+
+// PriorityRank returns the position of priority in Priorities counting from
+// 1, or 0 for unknown priorities.
+func PriorityRank(priority string) int {
+	return slices.Index(Priorities(), priority) + 1
+}
+
+// Visibility levels, from least to most restricted. A signal is visible to
+// callers cleared for its level or a more restricted one.
+const (
+	VisibilityPublic   = "public"
+	VisibilityInternal = "internal"
+	VisibilityAdmin    = "admin"
+)
+
+var visibilityRanks = map[string]int{
+	VisibilityPublic:   0,
+	VisibilityInternal: 1,
+	VisibilityAdmin:    2,
+}
+
+// Visibilities returns the visibility levels from least to most restricted.
+func Visibilities() []string {
+	return []string{VisibilityPublic, VisibilityInternal, VisibilityAdmin}
+}
+
+// EffectiveVisibility returns the level a signal is served at. Signals
+// published before visibility existed carry none and are public.
+func EffectiveVisibility(visibility string) string {
+	if visibility == "" {
+		return VisibilityPublic
+	}
+	return visibility
+}
+
+// Clearance returns the most restricted level a caller with role may see.
+// Roles that are not a visibility level are only cleared for public signals.
+func Clearance(role string) string {
+	if _, ok := visibilityRanks[role]; ok {
+		return role
+	}
+	return VisibilityPublic
+}
+
+// Visible reports whether a signal of the given visibility may be served to
+// a caller with the given clearance. Unknown visibilities are treated as
+// the most restricted level.
+func Visible(visibility, clearance string) bool {
+	rank, ok := visibilityRanks[EffectiveVisibility(visibility)]
+	if !ok {
+		rank = visibilityRanks[VisibilityAdmin]
+	}
+	return rank <= visibilityRanks[Clearance(clearance)]
+}
+
 // SignalEvent represents an event received from the nexus.signals topic.
+// EventID, Source and OccurredAt are only set for CloudEvents. TraceID is
+// set by the consumer from the event's trace context. UnknownFields lists,
+// comma-separated, the payload keys of a native envelope that no field
+// above was decoded from.
 type SignalEvent struct {
-	Action    Action `json:"action"`
-	ID        string `json:"id"`
-	Title     string `json:"title,omitempty"`
-	Content   string `json:"content,omitempty"`
-	Priority  string `json:"priority,omitempty"`
-	Author    string `json:"author,omitempty"`
-	CreatedAt string `json:"created_at,omitempty"`
-	UpdatedAt string `json:"updated_at,omitempty"`
-}
+	Action     Action `json:"action"`
+	ID         string `json:"id"`
+	Title      string `json:"title,omitempty"`
+	Content    string `json:"content,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+	Author     string `json:"author,omitempty"`
+	CreatedAt  string `json:"created_at,omitempty"`
+	UpdatedAt  string `json:"updated_at,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+	EventID    string `json:"event_id,omitempty"`
+	Source     string `json:"source,omitempty"`
+	OccurredAt string `json:"occurred_at,omitempty"`
+	TraceID    string `json:"trace_id,omitempty"`
 
-// ParseSignalEvent deserializes a JSON payload into a SignalEvent.
-func ParseSignalEvent(data []byte) (SignalEvent, error) {
-	var event SignalEvent
-	err := json.Unmarshal(data, &event)
-	return event, err
+	UnknownFields string `json:"-"`
 }
 
 // Fields returns the event data as a flat map for Redis hash storage.
@@ -40,29 +105,45 @@ func (e SignalEvent) Fields() map[string]string {
 		"author":     e.Author,
 		"created_at": e.CreatedAt,
 		"updated_at": e.UpdatedAt,
+		"visibility": EffectiveVisibility(e.Visibility),
+		"trace_id":   e.TraceID,
 	}
 }
 
 // Signal is the read model served by the API.
 type Signal struct {
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Content   string `json:"content"`
-	Priority  string `json:"priority"`
-	Author    string `json:"author"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Content    string `json:"content"`
+	Priority   string `json:"priority"`
+	Author     string `json:"author"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+	Visibility string `json:"visibility"`
+	TraceID    string `json:"trace_id,omitempty"`
+
+	UnknownFields string `json:"-"`
+}
+
+// BatchItem is one entry of a batch get: the signal with the requested ID,
+// or a marker that it was not found.
+type BatchItem struct {
+	ID     string  `json:"id"`
+	Found  bool    `json:"found"`
+	Signal *Signal `json:"signal,omitempty"`
 }
 
 // SignalFromMap builds a Signal from a Redis hash result.
 func SignalFromMap(data map[string]string) Signal {
 	return Signal{
-		ID:        data["id"],
-		Title:     data["title"],
-		Content:   data["content"],
-		Priority:  data["priority"],
-		Author:    data["author"],
-		CreatedAt: data["created_at"],
-		UpdatedAt: data["updated_at"],
+		ID:         data["id"],
+		Title:      data["title"],
+		Content:    data["content"],
+		Priority:   data["priority"],
+		Author:     data["author"],
+		CreatedAt:  data["created_at"],
+		UpdatedAt:  data["updated_at"],
+		Visibility: EffectiveVisibility(data["visibility"]),
+		TraceID:    data["trace_id"],
 	}
 }
@@ -170,3 +170,40 @@ func TestSignalEventFieldsRoundTrip(t *testing.T) {
 		t.Errorf("round trip failed for Author: %q != %q", signal.Author, original.Author)
 	}
 }
+
+func TestVisible(t *testing.T) {
+	tests := map[string]struct {
+		visibility string
+		clearance  string
+		expected   bool
+	}{
+		"public to public":       {domain.VisibilityPublic, domain.VisibilityPublic, true},
+		"unset to public":        {"", domain.VisibilityPublic, true},
+		"internal to public":     {domain.VisibilityInternal, domain.VisibilityPublic, false},
+		"internal to internal":   {domain.VisibilityInternal, domain.VisibilityInternal, true},
+		"admin to internal":      {domain.VisibilityAdmin, domain.VisibilityInternal, false},
+		"admin to admin":         {domain.VisibilityAdmin, domain.VisibilityAdmin, true},
+		"unknown to admin":       {"secret", domain.VisibilityAdmin, true},
+		"unknown to internal":    {"secret", domain.VisibilityInternal, false},
+		"internal to no role":    {domain.VisibilityInternal, "", false},
+		"public to unknown role": {domain.VisibilityPublic, "root", true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			visible := domain.Visible(test.visibility, domain.Clearance(test.clearance))
+
+			if visible != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, visible)
+			}
+		})
+	}
+}
+
+func TestSignalFromMap_DefaultsVisibility(t *testing.T) {
+	signal := domain.SignalFromMap(map[string]string{"id": "abc-123"})
+
+	if signal.Visibility != domain.VisibilityPublic {
+		t.Errorf("expected visibility %q, got %q", domain.VisibilityPublic, signal.Visibility)
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+// Stats aggregates signal counts maintained by the projection.
+type Stats struct {
+	Total      int64            `json:"total"`
+	ByPriority map[string]int64 `json:"by_priority"`
+	ByAuthor   map[string]int64 `json:"by_author"`
+	ByDay      []DayCount       `json:"by_day"`
+}
+
+// DayCount is the number of signals created on a single UTC day.
+type DayCount struct {
+	Day   string `json:"day"`
+	Count int64  `json:"count"`
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ViolationCode identifies a kind of validation failure.
+type ViolationCode string
+
+const (
+	ViolationMissingID         ViolationCode = "missing_id"
+	ViolationUnknownAction     ViolationCode = "unknown_action"
+	ViolationUnknownPriority   ViolationCode = "unknown_priority"
+	ViolationInvalidCreatedAt  ViolationCode = "invalid_created_at"
+	ViolationInvalidUpdatedAt  ViolationCode = "invalid_updated_at"
+	ViolationUnknownVisibility ViolationCode = "unknown_visibility"
+)
+
+var knownActions = map[Action]bool{
+	ActionCreated: true,
+	ActionUpdated: true,
+	ActionDeleted: true,
+}
+
+// Violation describes one way an event fails validation.
+type Violation struct {
+	Code   ViolationCode
+	Detail string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Code, v.Detail)
+}
+
+// Validate reports every violation in the event, or nil when it can be
+// projected as-is. Deleted events only need an ID.
+func Validate(event SignalEvent) []Violation {
+	var violations []Violation
+	if event.ID == "" {
+		violations = append(violations, Violation{ViolationMissingID, "id is empty"})
+	}
+	if !knownActions[event.Action] {
+		violations = append(violations, Violation{ViolationUnknownAction, fmt.Sprintf("action %q", event.Action)})
+		return violations
+	}
+	if event.Action == ActionDeleted {
+		return violations
+	}
+	if PriorityRank(event.Priority) == 0 {
+		violations = append(violations, Violation{ViolationUnknownPriority, fmt.Sprintf("priority %q", event.Priority)})
+	}
+	if _, err := time.Parse(time.RFC3339, event.CreatedAt); err != nil {
+		violations = append(violations, Violation{ViolationInvalidCreatedAt, fmt.Sprintf("created_at %q", event.CreatedAt)})
+	}
+	if _, err := time.Parse(time.RFC3339, event.UpdatedAt); err != nil {
+		violations = append(violations, Violation{ViolationInvalidUpdatedAt, fmt.Sprintf("updated_at %q", event.UpdatedAt)})
+	}
+	if _, ok := visibilityRanks[EffectiveVisibility(event.Visibility)]; !ok {
+		violations = append(violations, Violation{ViolationUnknownVisibility, fmt.Sprintf("visibility %q", event.Visibility)})
+	}
+	return violations
+}
+
+// JoinViolations formats violations as a single line for logs and headers.
+func JoinViolations(violations []Violation) string {
+	parts := make([]string, len(violations))
+	for index, violation := range violations {
+		parts[index] = violation.String()
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,123 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func validEvent() domain.SignalEvent {
+	return domain.SignalEvent{
+		Action:    domain.ActionCreated,
+		ID:        "abc-123",
+		Title:     "Server Alert",
+		Priority:  "High",
+		Author:    "otavio",
+		CreatedAt: "2026-02-23T15:00:00-03:00",
+		UpdatedAt: "2026-02-23T15:00:00.123456-03:00",
+	}
+}
+
+func codes(violations []domain.Violation) []domain.ViolationCode {
+	found := make([]domain.ViolationCode, len(violations))
+	for index, violation := range violations {
+		found[index] = violation.Code
+	}
+	return found
+}
+
+func TestValidate_ValidEvent(t *testing.T) {
+	violations := domain.Validate(validEvent())
+
+	if len(violations) != 0 {
+		t.Errorf("expected no violations, got %v", violations)
+	}
+}
+
+func TestValidate_ReportsEveryViolation(t *testing.T) {
+	event := validEvent()
+	event.ID = ""
+	event.Priority = "Urgent"
+	event.CreatedAt = "yesterday"
+	event.UpdatedAt = ""
+
+	violations := domain.Validate(event)
+
+	expected := []domain.ViolationCode{
+		domain.ViolationMissingID,
+		domain.ViolationUnknownPriority,
+		domain.ViolationInvalidCreatedAt,
+		domain.ViolationInvalidUpdatedAt,
+	}
+	found := codes(violations)
+	if len(found) != len(expected) {
+		t.Fatalf("expected violations %v, got %v", expected, found)
+	}
+	for index := range expected {
+		if found[index] != expected[index] {
+			t.Errorf("expected violation %q at %d, got %q", expected[index], index, found[index])
+		}
+	}
+}
+
+func TestValidate_UnknownAction(t *testing.T) {
+	event := validEvent()
+	event.Action = "archived"
+
+	violations := domain.Validate(event)
+
+	if len(violations) != 1 || violations[0].Code != domain.ViolationUnknownAction {
+		t.Errorf("expected only %q, got %v", domain.ViolationUnknownAction, codes(violations))
+	}
+}
+
+func TestValidate_DeletedNeedsOnlyID(t *testing.T) {
+	event := domain.SignalEvent{Action: domain.ActionDeleted, ID: "abc-123"}
+
+	violations := domain.Validate(event)
+
+	if len(violations) != 0 {
+		t.Errorf("expected no violations for a minimal delete, got %v", violations)
+	}
+}
+
+func TestJoinViolations(t *testing.T) {
+	violations := []domain.Violation{
+		{Code: domain.ViolationMissingID, Detail: "id is empty"},
+		{Code: domain.ViolationUnknownPriority, Detail: `priority "Urgent"`},
+	}
+
+	joined := domain.JoinViolations(violations)
+
+	expected := `missing_id: id is empty; unknown_priority: priority "Urgent"`
+	if joined != expected {
+		t.Errorf("expected %q, got %q", expected, joined)
+	}
+}
+
+func TestValidate_UnknownVisibility(t *testing.T) {
+	event := validEvent()
+	event.Visibility = "secret"
+
+	violations := domain.Validate(event)
+
+	if len(violations) != 1 || violations[0].Code != domain.ViolationUnknownVisibility {
+		t.Errorf("expected only %q, got %v", domain.ViolationUnknownVisibility, codes(violations))
+	}
+}
+
+func TestValidate_EveryPriority(t *testing.T) {
+	for rank, priority := range domain.Priorities() {
+		event := validEvent()
+		event.Priority = priority
+
+		violations := domain.Validate(event)
+
+		if len(violations) != 0 {
+			t.Errorf("expected %q to be valid, got %v", priority, codes(violations))
+		}
+		if got := domain.PriorityRank(priority); got != rank+1 {
+			t.Errorf("expected %q to rank %d, got %d", priority, rank+1, got)
+		}
+	}
+}
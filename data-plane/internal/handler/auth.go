@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+type principalKey struct{}
+
+const (
+	authorizationHeader = "Authorization"
+	bearerScheme        = "Bearer"
+	bearerChallenge     = bearerScheme + ` realm="nexus-data-plane"`
+)
+
+// WithAuthenticator requires requests to the read routes to carry a bearer
+// token that authenticator accepts and that grants auth.ScopeReadSignals.
+// The health route stays open.
+func WithAuthenticator(authenticator auth.Authenticator) Option {
+	return func(h *SignalHandler) {
+		h.authenticator = &authenticator
+	}
+}
+
+// authenticated answers 401 to requests without valid credentials and 403
+// to callers lacking the read scope, with a WWW-Authenticate challenge
+// (RFC 6750) in both cases. Accepted callers become the request's identity,
+// and their role its clearance.
+func (h SignalHandler) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	if h.authenticator == nil {
+		return next
+	}
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if !h.mayAuthenticate(writer, request) {
+			return
+		}
+		principal, err := h.authenticator.Authenticate(bearerToken(request))
+		if errors.Is(err, auth.ErrMissingCredentials) {
+			writer.Header().Set("WWW-Authenticate", bearerChallenge)
+			writeError(writer, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		if err != nil {
+			slog.DebugContext(request.Context(), "authentication failed", "reason", err)
+			h.countFailedAuthentication(request)
+			writer.Header().Set("WWW-Authenticate", bearerChallenge+`, error="invalid_token"`)
+			writeError(writer, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+
+		request = setIdentity(request, principal.Subject)
+		request = request.WithContext(context.WithValue(request.Context(), principalKey{}, principal))
+		if !principal.HasScope(auth.ScopeReadSignals) {
+			writer.Header().Set("WWW-Authenticate",
+				bearerChallenge+`, error="insufficient_scope", scope="`+auth.ScopeReadSignals+`"`)
+			writeError(writer, http.StatusForbidden, "missing scope "+auth.ScopeReadSignals)
+			return
+		}
+		next(writer, request)
+	}
+}
+
+// clearance returns the most restricted visibility the caller of the request
+// ctx belongs to may read. Anonymous callers only see public signals.
+func clearance(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(auth.Principal)
+	return domain.Clearance(principal.Role)
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header, or ""
+// when there is none.
+func bearerToken(request *http.Request) string {
+	scheme, token, found := strings.Cut(request.Header.Get(authorizationHeader), " ")
+	if !found || !strings.EqualFold(scheme, bearerScheme) {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
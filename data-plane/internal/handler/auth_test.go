@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+)
+
+const (
+	readerKey  = "nxs_reader"
+	profileKey = "nxs_profile"
+)
+
+func setupAuthenticatedHandler(t *testing.T) *http.ServeMux {
+	t.Helper()
+	return setupAuthenticatedHandlerWith(t)
+}
+
+func setupAuthenticatedHandlerWith(t *testing.T, options ...handler.Option) *http.ServeMux {
+	t.Helper()
+	authenticator, err := auth.New([]auth.APIKey{
+		{Name: "dashboard", SHA256: auth.HashKey(readerKey)},
+		{Name: "profile-service", SHA256: auth.HashKey(profileKey), Scopes: []string{"profile:read"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mux, _ := setupHandler(t, append(options, handler.WithAuthenticator(authenticator))...)
+	return mux
+}
+
+func TestAuthenticated_Responses(t *testing.T) {
+	mux := setupAuthenticatedHandler(t)
+	tests := map[string]struct {
+		path          string
+		authorization string
+		status        int
+		challenge     string
+	}{
+		"no credentials":    {path: "/signals", status: http.StatusUnauthorized, challenge: `Bearer realm="nexus-data-plane"`},
+		"other scheme":      {path: "/stats", authorization: "Basic " + readerKey, status: http.StatusUnauthorized, challenge: `Bearer realm="nexus-data-plane"`},
+		"unknown key":       {path: "/signals/s1", authorization: "Bearer nxs_guess", status: http.StatusUnauthorized, challenge: `error="invalid_token"`},
+		"missing scope":     {path: "/positions", authorization: "Bearer " + profileKey, status: http.StatusForbidden, challenge: `error="insufficient_scope"`},
+		"valid key":         {path: "/signals", authorization: "Bearer " + readerKey, status: http.StatusOK},
+		"lowercase scheme":  {path: "/signals", authorization: "bearer " + readerKey, status: http.StatusOK},
+		"health stays open": {path: "/health", status: http.StatusOK},
+		"spec stays open":   {path: "/openapi.json", status: http.StatusOK},
+		"docs stay open":    {path: "/docs", status: http.StatusOK},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, recorder.Code)
+			}
+			challenge := recorder.Header().Get("WWW-Authenticate")
+			if !strings.Contains(challenge, test.challenge) || (test.challenge == "") != (challenge == "") {
+				t.Errorf("expected challenge containing %q, got %q", test.challenge, challenge)
+			}
+		})
+	}
+}
+
+func TestAuthenticated_ErrorBody(t *testing.T) {
+	mux := setupAuthenticatedHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/signals", nil)
+	request.Header.Set(handler.RequestIDHeader, "req-42")
+	recorder := httptest.NewRecorder()
+
+	handler.LogRequests(mux).ServeHTTP(recorder, request)
+
+	var body map[string]string
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body["error"] != "authentication required" || body["request_id"] != "req-42" {
+		t.Errorf("expected the standard error body, got %v", body)
+	}
+}
+
+func TestAuthenticated_SetsIdentity(t *testing.T) {
+	logs := captureLogs(t)
+	mux := setupAuthenticatedHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/signals", nil)
+	request.Header.Set("Authorization", "Bearer "+readerKey)
+
+	handler.LogRequests(mux).ServeHTTP(httptest.NewRecorder(), request)
+
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log entry, got %q", logs.String())
+	}
+	if entry["identity"] != "dashboard" {
+		t.Errorf("expected identity %q in the log entry, got %v", "dashboard", entry["identity"])
+	}
+}
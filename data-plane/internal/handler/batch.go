@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+const (
+	// MaxBatchIDs caps how many signals a single batch get may ask for.
+	MaxBatchIDs = 500
+	// maxBatchBody bounds the body of POST /signals/batch: MaxBatchIDs
+	// UUIDs with room to spare.
+	maxBatchBody = 64 << 10
+)
+
+// batchRequest is the body of POST /signals/batch.
+type batchRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// batchSignals answers GET /signals?ids=a,b,c.
+func (h SignalHandler) batchSignals(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	if query.Has("priority") {
+		writeError(writer, http.StatusBadRequest, "ids cannot be combined with priority")
+		return
+	}
+	fields, ok := requestedFields(writer, request)
+	if !ok {
+		return
+	}
+	var ids []string
+	for _, id := range strings.Split(query.Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	items, ok := h.fetchBatch(request.Context(), writer, ids, fields)
+	if !ok {
+		return
+	}
+	writeCacheable(writer, request, envelope(request, sparseBatch(items, fields), nil), lastFound(items), false)
+}
+
+// postBatch answers POST /signals/batch, for lists of IDs too long for a
+// query string. Fields are still selected with ?fields=.
+func (h SignalHandler) postBatch(writer http.ResponseWriter, request *http.Request) {
+	fields, ok := requestedFields(writer, request)
+	if !ok {
+		return
+	}
+	var body batchRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(writer, request.Body, maxBatchBody))
+	if err := decoder.Decode(&body); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(writer, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		writeError(writer, http.StatusBadRequest, `invalid body, expected {"ids": [...]}`)
+		return
+	}
+	items, ok := h.fetchBatch(request.Context(), writer, body.IDs, fields)
+	if !ok {
+		return
+	}
+	writeJSON(writer, http.StatusOK, envelope(request, sparseBatch(items, fields), nil))
+}
+
+// fetchBatch returns one item per id, in order, marking the signals that
+// do not exist or are not visible to the caller as not found. On failure it
+// writes the error response and reports false.
+func (h SignalHandler) fetchBatch(ctx context.Context, writer http.ResponseWriter, ids, fields []string) ([]domain.BatchItem, bool) {
+	if len(ids) == 0 {
+		writeError(writer, http.StatusBadRequest, "ids: at least one id is required")
+		return nil, false
+	}
+	if len(ids) > MaxBatchIDs {
+		writeError(writer, http.StatusBadRequest, fmt.Sprintf("ids: at most %d ids per request", MaxBatchIDs))
+		return nil, false
+	}
+	signals, err := h.projection.Only(fields...).FindByIDs(ctx, ids)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "failed to get signals")
+		return nil, false
+	}
+	clearance := clearance(ctx)
+	items := make([]domain.BatchItem, len(ids))
+	for index, id := range ids {
+		items[index] = domain.BatchItem{ID: id}
+		if signal, found := signals[id]; found && domain.Visible(signal.Visibility, clearance) {
+			items[index].Found = true
+			items[index].Signal = &signal
+		}
+	}
+	return items, true
+}
+
+// lastFound returns the newest updated_at among the signals found.
+func lastFound(items []domain.BatchItem) time.Time {
+	found := make([]domain.Signal, 0, len(items))
+	for _, item := range items {
+		if item.Found {
+			found = append(found, *item.Signal)
+		}
+	}
+	return lastUpdated(found...)
+}
@@ -0,0 +1,116 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+)
+
+func decodeBatch(t *testing.T, recorder *httptest.ResponseRecorder) []domain.BatchItem {
+	t.Helper()
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	var items []domain.BatchItem
+	if err := json.NewDecoder(recorder.Body).Decode(&items); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return items
+}
+
+func assertBatch(t *testing.T, items []domain.BatchItem, expected map[string]bool, order []string) {
+	t.Helper()
+	if len(items) != len(order) {
+		t.Fatalf("expected %d items, got %d", len(order), len(items))
+	}
+	for index, id := range order {
+		item := items[index]
+		if item.ID != id {
+			t.Errorf("expected %q at %d, got %q", id, index, item.ID)
+		}
+		if item.Found != expected[id] || (item.Signal != nil) != expected[id] {
+			t.Errorf("expected %q found %v, got %+v", id, expected[id], item)
+		}
+	}
+}
+
+func TestBatchSignals_Query(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	seedSignal(t, proj, "s2", "Low", "2026-02-23T16:00:00-03:00")
+	request := httptest.NewRequest(http.MethodGet, "/signals?ids=s2,missing,%20s1", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	items := decodeBatch(t, recorder)
+	assertBatch(t, items, map[string]bool{"s1": true, "s2": true}, []string{"s2", "missing", "s1"})
+	if items[0].Signal.Priority != "Low" {
+		t.Errorf("expected priority %q, got %q", "Low", items[0].Signal.Priority)
+	}
+	if recorder.Header().Get("ETag") == "" {
+		t.Errorf("expected an ETag")
+	}
+}
+
+func TestBatchSignals_Post(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	request := httptest.NewRequest(http.MethodPost, "/signals/batch", strings.NewReader(`{"ids": ["missing", "s1", "s1"]}`))
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	items := decodeBatch(t, recorder)
+	assertBatch(t, items, map[string]bool{"s1": true}, []string{"missing", "s1", "s1"})
+}
+
+func TestBatchSignals_HidesRestrictedSignals(t *testing.T) {
+	mux := setupVisibilityHandler(t, withRoles(t))
+	request := httptest.NewRequest(http.MethodGet, "/signals?ids=admin,internal,public", nil)
+	request.Header.Set("Authorization", "Bearer "+internalKey)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	items := decodeBatch(t, recorder)
+	assertBatch(t, items, map[string]bool{"internal": true, "public": true}, []string{"admin", "internal", "public"})
+}
+
+func TestBatchSignals_Rejects(t *testing.T) {
+	mux, _ := setupHandler(t)
+	tooMany := strings.TrimSuffix(strings.Repeat("id,", handler.MaxBatchIDs+1), ",")
+	tests := map[string]struct {
+		method string
+		target string
+		body   string
+		status int
+	}{
+		"empty ids":          {http.MethodGet, "/signals?ids=", "", http.StatusBadRequest},
+		"only separators":    {http.MethodGet, "/signals?ids=,,", "", http.StatusBadRequest},
+		"ids with priority":  {http.MethodGet, "/signals?ids=s1&priority=High", "", http.StatusBadRequest},
+		"too many ids":       {http.MethodGet, "/signals?ids=" + tooMany, "", http.StatusBadRequest},
+		"malformed body":     {http.MethodPost, "/signals/batch", `["s1"]`, http.StatusBadRequest},
+		"empty body":         {http.MethodPost, "/signals/batch", `{}`, http.StatusBadRequest},
+		"oversized body":     {http.MethodPost, "/signals/batch", `{"ids": ["` + strings.Repeat("x", 70<<10) + `"]}`, http.StatusRequestEntityTooLarge},
+		"wrong batch method": {http.MethodPut, "/signals/batch", `{"ids": ["s1"]}`, http.StatusMethodNotAllowed},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, recorder.Code)
+			}
+		})
+	}
+}
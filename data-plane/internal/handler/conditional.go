@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+// etagLength is how many hex characters of the body's SHA-256 an ETag
+// keeps: 128 bits, plenty to tell versions of one resource apart.
+const etagLength = 32
+
+// writeCacheable writes data as a 200 response validated by a strong ETag,
+// the hash of the body, and by Last-Modified when lastModified is set.
+// Requests whose If-None-Match matches the ETag get 304 instead. So do
+// requests whose If-Modified-Since is not older than lastModified, when
+// dated reports that lastModified moves on every change of data.
+//
+// Bodies depend on the caller's clearance, so responses vary on
+// Authorization and caches must revalidate them before reuse.
+func writeCacheable(writer http.ResponseWriter, request *http.Request, data any, lastModified time.Time, dated bool) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "failed to encode response")
+		return
+	}
+	body = append(body, '\n')
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:])[:etagLength] + `"`
+
+	headers := writer.Header()
+	headers.Set("ETag", etag)
+	headers.Set("Cache-Control", "no-cache")
+	headers.Add("Vary", authorizationHeader)
+	if !lastModified.IsZero() {
+		headers.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	if notModified(request, etag, lastModified, dated) {
+		writer.WriteHeader(http.StatusNotModified)
+		return
+	}
+	headers.Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	_, _ = writer.Write(body)
+}
+
+// notModified evaluates the request's preconditions as RFC 9110 does for
+// GET: If-None-Match, with weak comparison, takes precedence over
+// If-Modified-Since.
+func notModified(request *http.Request, etag string, lastModified time.Time, dated bool) bool {
+	if header := request.Header.Get("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+	if !dated || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(request.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// lastUpdated returns the newest updated_at among signals, or the zero time
+// when none parses.
+func lastUpdated(signals ...domain.Signal) time.Time {
+	var newest time.Time
+	for _, signal := range signals {
+		updatedAt, err := time.Parse(time.RFC3339Nano, signal.UpdatedAt)
+		if err == nil && updatedAt.After(newest) {
+			newest = updatedAt
+		}
+	}
+	return newest
+}
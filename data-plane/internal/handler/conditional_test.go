@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func conditionalGet(mux *http.ServeMux, path string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestGetSignal_Validators(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00.5-03:00")
+
+	recorder := conditionalGet(mux, "/signals/s1", nil)
+
+	if recorder.Header().Get("ETag") == "" {
+		t.Errorf("expected an ETag")
+	}
+	if got := recorder.Header().Get("Last-Modified"); got != "Mon, 23 Feb 2026 18:00:00 GMT" {
+		t.Errorf("expected Last-Modified from updated_at, got %q", got)
+	}
+	if got := recorder.Header().Get("Vary"); got != "Authorization" {
+		t.Errorf("expected Vary %q, got %q", "Authorization", got)
+	}
+}
+
+func TestGetSignal_Conditional(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	etag := conditionalGet(mux, "/signals/s1", nil).Header().Get("ETag")
+	tests := map[string]struct {
+		headers map[string]string
+		status  int
+	}{
+		"matching etag":         {map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		"weak matching etag":    {map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified},
+		"any etag":              {map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		"stale etag":            {map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		"not modified since":    {map[string]string{"If-Modified-Since": "Mon, 23 Feb 2026 18:00:00 GMT"}, http.StatusNotModified},
+		"modified since":        {map[string]string{"If-Modified-Since": "Mon, 23 Feb 2026 17:59:59 GMT"}, http.StatusOK},
+		"etag takes precedence": {map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": "Mon, 23 Feb 2026 18:00:00 GMT"}, http.StatusOK},
+		"unparseable date":      {map[string]string{"If-Modified-Since": "yesterday"}, http.StatusOK},
+		"no preconditions":      {nil, http.StatusOK},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := conditionalGet(mux, "/signals/s1", test.headers)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, recorder.Code)
+			}
+			if recorder.Code == http.StatusNotModified && recorder.Body.Len() != 0 {
+				t.Errorf("expected an empty 304 body, got %q", recorder.Body.String())
+			}
+			if recorder.Header().Get("ETag") != etag {
+				t.Errorf("expected ETag %s, got %s", etag, recorder.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestGetSignal_ETagChangesWithSignal(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	before := conditionalGet(mux, "/signals/s1", nil).Header().Get("ETag")
+
+	seedSignal(t, proj, "s1", "Low", "2026-02-23T15:00:00-03:00")
+	recorder := conditionalGet(mux, "/signals/s1", map[string]string{"If-None-Match": before})
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestListSignals_Conditional(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	seedSignal(t, proj, "s2", "Low", "2026-02-23T16:00:00-03:00")
+	first := conditionalGet(mux, "/signals", nil)
+	etag := first.Header().Get("ETag")
+
+	unchanged := conditionalGet(mux, "/signals", map[string]string{"If-None-Match": etag})
+	sinceOnly := conditionalGet(mux, "/signals", map[string]string{"If-Modified-Since": first.Header().Get("Last-Modified")})
+	filtered := conditionalGet(mux, "/signals?priority=High", map[string]string{"If-None-Match": etag})
+
+	if first.Header().Get("Last-Modified") != "Mon, 23 Feb 2026 19:00:00 GMT" {
+		t.Errorf("expected Last-Modified from the newest signal, got %q", first.Header().Get("Last-Modified"))
+	}
+	if unchanged.Code != http.StatusNotModified {
+		t.Errorf("expected status %d, got %d", http.StatusNotModified, unchanged.Code)
+	}
+	if sinceOnly.Code != http.StatusOK {
+		t.Errorf("expected If-Modified-Since to be ignored on lists, got %d", sinceOnly.Code)
+	}
+	if filtered.Code != http.StatusOK {
+		t.Errorf("expected another list to fail the ETag, got %d", filtered.Code)
+	}
+}
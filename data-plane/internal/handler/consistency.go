@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+const (
+	// ConsistencyHeader carries a read-your-writes token on requests.
+	ConsistencyHeader = "X-Consistency-Token"
+	consistencyQuery  = "consistency"
+	consistencyPoll   = 50 * time.Millisecond
+	consistencyRetry  = "1"
+
+	defaultConsistencyTimeout = 2 * time.Second
+)
+
+// consistent holds a read until the projection reflects the request's
+// consistency token, or answers 503 with Retry-After once the timeout
+// elapses. Requests without a token pass straight through.
+func (h SignalHandler) consistent(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		value := request.Header.Get(ConsistencyHeader)
+		if value == "" {
+			value = request.URL.Query().Get(consistencyQuery)
+		}
+		if value == "" {
+			next(writer, request)
+			return
+		}
+
+		token, err := domain.ParseConsistencyToken(value)
+		if err != nil {
+			writeError(writer, http.StatusBadRequest, "invalid consistency token")
+			return
+		}
+		reached, err := h.waitFor(request.Context(), token)
+		if err != nil {
+			writeError(writer, http.StatusInternalServerError, "failed to check consistency")
+			return
+		}
+		if !reached {
+			writer.Header().Set("Retry-After", consistencyRetry)
+			writeError(writer, http.StatusServiceUnavailable, "projection has not caught up with the consistency token")
+			return
+		}
+		next(writer, request)
+	}
+}
+
+func (h SignalHandler) waitFor(ctx context.Context, token domain.ConsistencyToken) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.consistencyTimeout)
+	defer cancel()
+	ticker := time.NewTicker(consistencyPoll)
+	defer ticker.Stop()
+
+	for {
+		reached, err := h.projection.Reflects(ctx, token)
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		if err != nil || reached {
+			return reached, err
+		}
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case <-ticker.C:
+		}
+	}
+}
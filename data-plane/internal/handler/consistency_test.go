@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+)
+
+func TestConsistency_OffsetAlreadyApplied(t *testing.T) {
+	mux, proj := setupHandler(t)
+	event := domain.SignalEvent{Action: domain.ActionCreated, ID: "s1", Priority: "High"}
+	if err := proj.ApplyAt(t.Context(), event, domain.Position{Topic: "nexus.signals", Offset: 5}); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/signals/s1", nil)
+	request.Header.Set(handler.ConsistencyHeader, "0:5")
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestConsistency_WaitsForProjection(t *testing.T) {
+	mux, proj := setupHandler(t)
+	event := domain.SignalEvent{
+		Action:    domain.ActionCreated,
+		ID:        "s1",
+		Priority:  "High",
+		CreatedAt: "2026-02-23T15:00:00Z",
+		UpdatedAt: "2026-02-23T15:00:00Z",
+	}
+	// Open the first Redis connection before going concurrent: its handshake
+	// updates the client options that Watch clones.
+	if err := proj.Health(t.Context()); err != nil {
+		t.Fatalf("redis not ready: %v", err)
+	}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		if err := proj.Apply(context.Background(), event); err != nil {
+			t.Errorf("failed to apply event: %v", err)
+		}
+	}()
+
+	request := httptest.NewRequest(http.MethodGet, "/signals?consistency=s1@2026-02-23T15:00:00Z", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if !containsID(t, recorder, "s1") {
+		t.Error("expected response to include the signal applied while waiting")
+	}
+}
+
+func TestConsistency_TimesOut(t *testing.T) {
+	mux, proj := setupHandler(t, handler.WithConsistencyTimeout(100*time.Millisecond))
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00Z")
+
+	request := httptest.NewRequest(http.MethodGet, "/signals/s1", nil)
+	request.Header.Set(handler.ConsistencyHeader, "s1@2026-02-23T15:00:01Z")
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, recorder.Code)
+	}
+	if recorder.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header on 503")
+	}
+}
+
+func TestConsistency_InvalidToken(t *testing.T) {
+	mux, _ := setupHandler(t)
+
+	request := httptest.NewRequest(http.MethodGet, "/signals", nil)
+	request.Header.Set(handler.ConsistencyHeader, "yesterday")
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+}
+
+func containsID(t *testing.T, recorder *httptest.ResponseRecorder, id string) bool {
+	t.Helper()
+	var signals []domain.Signal
+	if err := json.NewDecoder(recorder.Body).Decode(&signals); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, signal := range signals {
+		if signal.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+// sparseBatchItem is a domain.BatchItem carrying a sparse signal.
+type sparseBatchItem struct {
+	ID     string            `json:"id"`
+	Found  bool              `json:"found"`
+	Signal map[string]string `json:"signal,omitempty"`
+}
+
+// requestedFields parses the request's ?fields=, answering 400 when it
+// names an unknown field. Nil fields select them all.
+func requestedFields(writer http.ResponseWriter, request *http.Request) ([]string, bool) {
+	fields, err := domain.ParseFields(request.URL.Query().Get("fields"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, "invalid fields: "+err.Error())
+		return nil, false
+	}
+	return fields, true
+}
+
+// sparseSignal returns signal limited to fields, or whole without fields.
+func sparseSignal(signal domain.Signal, fields []string) any {
+	if fields == nil {
+		return signal
+	}
+	return signal.Select(fields)
+}
+
+// sparseSignals returns signals limited to fields, or whole without fields.
+func sparseSignals(signals []domain.Signal, fields []string) any {
+	if fields == nil {
+		return signals
+	}
+	selected := make([]map[string]string, len(signals))
+	for index, signal := range signals {
+		selected[index] = signal.Select(fields)
+	}
+	return selected
+}
+
+// sparseBatch returns items with their signals limited to fields, or whole
+// without fields.
+func sparseBatch(items []domain.BatchItem, fields []string) any {
+	if fields == nil {
+		return items
+	}
+	selected := make([]sparseBatchItem, len(items))
+	for index, item := range items {
+		selected[index] = sparseBatchItem{ID: item.ID, Found: item.Found}
+		if item.Found {
+			selected[index].Signal = item.Signal.Select(fields)
+		}
+	}
+	return selected
+}
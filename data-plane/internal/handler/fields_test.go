@@ -0,0 +1,102 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func decodeSparse(t *testing.T, recorder *httptest.ResponseRecorder, target any) {
+	t.Helper()
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+	if err := json.NewDecoder(recorder.Body).Decode(target); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+}
+
+func assertOnlyFields(t *testing.T, signal map[string]string, expected map[string]string) {
+	t.Helper()
+	if len(signal) != len(expected) {
+		t.Errorf("expected fields %v, got %v", expected, signal)
+	}
+	for field, value := range expected {
+		if signal[field] != value {
+			t.Errorf("expected %s %q, got %q", field, value, signal[field])
+		}
+	}
+}
+
+func TestListSignals_Fields(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	request := httptest.NewRequest(http.MethodGet, "/signals?fields=id,priority", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	var signals []map[string]string
+	decodeSparse(t, recorder, &signals)
+	if len(signals) != 1 {
+		t.Fatalf("expected 1 signal, got %d", len(signals))
+	}
+	assertOnlyFields(t, signals[0], map[string]string{"id": "s1", "priority": "High"})
+}
+
+func TestGetSignal_Fields(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	request := httptest.NewRequest(http.MethodGet, "/signals/s1?fields=title", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	var signal map[string]string
+	decodeSparse(t, recorder, &signal)
+	assertOnlyFields(t, signal, map[string]string{"title": "Signal s1"})
+	if recorder.Header().Get("Last-Modified") == "" {
+		t.Errorf("expected Last-Modified even without updated_at in the body")
+	}
+}
+
+func TestBatchSignals_Fields(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	request := httptest.NewRequest(http.MethodPost, "/signals/batch?fields=author", strings.NewReader(`{"ids": ["s1", "missing"]}`))
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	var items []struct {
+		ID     string            `json:"id"`
+		Found  bool              `json:"found"`
+		Signal map[string]string `json:"signal"`
+	}
+	decodeSparse(t, recorder, &items)
+	if len(items) != 2 || !items[0].Found || items[1].Found || items[1].Signal != nil {
+		t.Fatalf("expected s1 found and missing not found, got %+v", items)
+	}
+	assertOnlyFields(t, items[0].Signal, map[string]string{"author": "otavio"})
+}
+
+func TestFields_RejectsUnknownField(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	targets := []string{"/signals?fields=id,secret", "/signals/s1?fields=secret", "/signals?ids=s1&fields=secret"}
+
+	for _, target := range targets {
+		t.Run(target, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, target, nil)
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+)
+
+type identityKey struct{}
+
+// identitySlot holds the caller identity of a request. LogRequests installs
+// it before the request reaches the middleware that authenticates the
+// caller, so the identity can be logged once the request is served.
+type identitySlot struct {
+	identity string
+}
+
+// Identity returns the authenticated caller of the request ctx belongs to,
+// or "" when the caller is anonymous.
+func Identity(ctx context.Context) string {
+	slot, _ := ctx.Value(identityKey{}).(*identitySlot)
+	if slot == nil {
+		return ""
+	}
+	return slot.identity
+}
+
+// withIdentitySlot returns a request carrying an empty identity slot, and
+// the slot.
+func withIdentitySlot(request *http.Request) (*http.Request, *identitySlot) {
+	slot := &identitySlot{}
+	return request.WithContext(context.WithValue(request.Context(), identityKey{}, slot)), slot
+}
+
+// setIdentity records identity as the caller of request, in the slot
+// LogRequests installed when there is one.
+func setIdentity(request *http.Request, identity string) *http.Request {
+	if slot, _ := request.Context().Value(identityKey{}).(*identitySlot); slot != nil {
+		slot.identity = identity
+		return request
+	}
+	request, slot := withIdentitySlot(request)
+	slot.identity = identity
+	return request
+}
+
+// IdentifyClientCertificates wraps next so that requests presenting a
+// verified client certificate are attributed to its subject's common name,
+// or to the full subject when it has none.
+func IdentifyClientCertificates(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.TLS != nil && len(request.TLS.VerifiedChains) > 0 {
+			subject := request.TLS.VerifiedChains[0][0].Subject
+			identity := subject.CommonName
+			if identity == "" {
+				identity = subject.String()
+			}
+			request = setIdentity(request, identity)
+		}
+		next.ServeHTTP(writer, request)
+	})
+}
@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+)
+
+func withClientCertificate(request *http.Request, subject pkix.Name) *http.Request {
+	request.TLS = &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: subject}}},
+	}
+	return request
+}
+
+func TestIdentifyClientCertificates_UsesCommonName(t *testing.T) {
+	logs := captureLogs(t)
+	mux, _ := setupHandler(t)
+	request := withClientCertificate(httptest.NewRequest(http.MethodGet, "/health", nil),
+		pkix.Name{CommonName: "reporting", Organization: []string{"Nexus"}})
+	recorder := httptest.NewRecorder()
+
+	handler.LogRequests(handler.IdentifyClientCertificates(mux)).ServeHTTP(recorder, request)
+
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log entry, got %q", logs.String())
+	}
+	if entry["identity"] != "reporting" {
+		t.Errorf("expected identity %q in the log entry, got %v", "reporting", entry["identity"])
+	}
+}
+
+func TestIdentifyClientCertificates_FallsBackToSubject(t *testing.T) {
+	var identity string
+	next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		identity = handler.Identity(request.Context())
+	})
+	request := withClientCertificate(httptest.NewRequest(http.MethodGet, "/health", nil),
+		pkix.Name{Organization: []string{"Nexus"}, OrganizationalUnit: []string{"Reporting"}})
+
+	handler.IdentifyClientCertificates(next).ServeHTTP(httptest.NewRecorder(), request)
+
+	if identity != "OU=Reporting,O=Nexus" {
+		t.Errorf("expected the full subject as identity, got %q", identity)
+	}
+}
+
+func TestIdentifyClientCertificates_Anonymous(t *testing.T) {
+	identity := "unset"
+	next := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		identity = handler.Identity(request.Context())
+	})
+
+	handler.IdentifyClientCertificates(next).ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	if identity != "" {
+		t.Errorf("expected no identity without a client certificate, got %q", identity)
+	}
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tracing"
+)
+
+// RequestIDHeader carries the request's correlation ID. An incoming value is
+// kept; otherwise one is generated. Either way it is echoed on the response
+// and in error bodies.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds caller-supplied IDs so they cannot flood logs.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// RequestID returns the correlation ID of the request ctx belongs to.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// LogRequests wraps next with request ID handling and one log entry per
+// request, with its route, status, duration and caller identity.
+func LogRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		started := time.Now()
+		id := request.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+		writer.Header().Set(RequestIDHeader, id)
+
+		recorder := &statusRecorder{ResponseWriter: writer, status: http.StatusOK}
+		routed, identity := withIdentitySlot(request.WithContext(context.WithValue(request.Context(), requestIDKey{}, id)))
+		next.ServeHTTP(recorder, routed)
+		recordRoute(routed)
+
+		attributes := []any{
+			"request_id", id,
+			"method", request.Method,
+			"path", request.URL.Path,
+			"route", routed.Pattern,
+			"status", recorder.status,
+			"duration_ms", time.Since(started).Milliseconds(),
+		}
+		if identity.identity != "" {
+			attributes = append(attributes, "identity", identity.identity)
+		}
+		if traceID := tracing.TraceID(routed.Context()); traceID != "" {
+			attributes = append(attributes, "trace_id", traceID)
+		}
+		level := slog.LevelInfo
+		if recorder.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(routed.Context(), level, "http request", attributes...)
+	})
+}
+
+func newRequestID() string {
+	bytes := make([]byte, 16)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
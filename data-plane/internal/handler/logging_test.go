@@ -0,0 +1,78 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var output bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&output, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &output
+}
+
+func TestLogRequests_GeneratesRequestID(t *testing.T) {
+	logs := captureLogs(t)
+	mux, _ := setupHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/signals/missing", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.LogRequests(mux).ServeHTTP(recorder, request)
+
+	id := recorder.Header().Get(handler.RequestIDHeader)
+	if len(id) != 32 {
+		t.Fatalf("expected a generated request id, got %q", id)
+	}
+	var body map[string]string
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if body["request_id"] != id {
+		t.Errorf("expected request id %q in the error body, got %v", id, body)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON log entry, got %q", logs.String())
+	}
+	if entry["request_id"] != id || entry["route"] != "GET /signals/{id}" || entry["status"] != float64(http.StatusNotFound) {
+		t.Errorf("expected request fields in the log entry, got %v", entry)
+	}
+}
+
+func TestLogRequests_KeepsIncomingRequestID(t *testing.T) {
+	captureLogs(t)
+	mux, _ := setupHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	request.Header.Set(handler.RequestIDHeader, "req-42")
+	recorder := httptest.NewRecorder()
+
+	handler.LogRequests(mux).ServeHTTP(recorder, request)
+
+	if id := recorder.Header().Get(handler.RequestIDHeader); id != "req-42" {
+		t.Errorf("expected the caller's request id, got %q", id)
+	}
+}
+
+func TestLogRequests_ReplacesOversizedRequestID(t *testing.T) {
+	captureLogs(t)
+	mux, _ := setupHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	request.Header.Set(handler.RequestIDHeader, strings.Repeat("x", 1000))
+	recorder := httptest.NewRecorder()
+
+	handler.LogRequests(mux).ServeHTTP(recorder, request)
+
+	if id := recorder.Header().Get(handler.RequestIDHeader); len(id) != 32 {
+		t.Errorf("expected a generated request id, got %d characters", len(id))
+	}
+}
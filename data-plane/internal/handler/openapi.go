@@ -0,0 +1,30 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the OpenAPI 3.1 document describing every route Register
+// mounts. Keep it in step with Routes: the handler tests fail on routes it
+// does not describe.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// docsPage renders openAPISpec in a browser without third-party assets.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// openAPI answers GET /openapi.json.
+func openAPI(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "application/json")
+	_, _ = writer.Write(openAPISpec)
+}
+
+// docs answers GET /docs.
+func docs(writer http.ResponseWriter, _ *http.Request) {
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = writer.Write(docsPage)
+}
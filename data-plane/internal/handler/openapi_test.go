@@ -0,0 +1,104 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+)
+
+func fetchSpec(t *testing.T) map[string]any {
+	t.Helper()
+	mux, _ := setupHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/json" {
+		t.Errorf("expected Content-Type %q, got %q", "application/json", contentType)
+	}
+	var spec map[string]any
+	if err := json.NewDecoder(recorder.Body).Decode(&spec); err != nil {
+		t.Fatalf("failed to decode spec: %v", err)
+	}
+	return spec
+}
+
+func TestOpenAPI_Version(t *testing.T) {
+	spec := fetchSpec(t)
+
+	if spec["openapi"] != "3.1.0" {
+		t.Errorf("expected OpenAPI %q, got %v", "3.1.0", spec["openapi"])
+	}
+}
+
+func TestOpenAPI_DescribesEveryRoute(t *testing.T) {
+	spec := fetchSpec(t)
+	paths, ok := spec["paths"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected paths in the spec, got %v", spec["paths"])
+	}
+
+	for _, route := range handler.New(projection.SignalProjection{}).Routes() {
+		method, path, _ := strings.Cut(route.Pattern, " ")
+		item, ok := paths[path].(map[string]any)
+		if !ok || item[strings.ToLower(method)] == nil {
+			t.Errorf("route %q is missing from openapi.json", route.Pattern)
+		}
+	}
+}
+
+func TestOpenAPI_ReferencesResolve(t *testing.T) {
+	spec := fetchSpec(t)
+	var walk func(node any)
+	walk = func(node any) {
+		switch node := node.(type) {
+		case map[string]any:
+			if ref, ok := node["$ref"].(string); ok {
+				var target any = spec
+				for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+					object, _ := target.(map[string]any)
+					target = object[key]
+				}
+				if target == nil {
+					t.Errorf("unresolved reference %q", ref)
+				}
+			}
+			for _, child := range node {
+				walk(child)
+			}
+		case []any:
+			for _, child := range node {
+				walk(child)
+			}
+		}
+	}
+
+	walk(spec)
+}
+
+func TestDocs_RendersSpec(t *testing.T) {
+	mux, _ := setupHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/docs", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("expected an HTML page, got %q", contentType)
+	}
+	if !strings.Contains(recorder.Body.String(), `fetch("openapi.json")`) {
+		t.Errorf("expected the page to load openapi.json")
+	}
+}
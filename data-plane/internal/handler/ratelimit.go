@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/ratelimit"
+)
+
+// WithRateLimiter limits how often each caller may hit the read routes:
+// authenticated callers by identity, anonymous ones by client address.
+func WithRateLimiter(limiter ratelimit.Limiter) Option {
+	return func(h *SignalHandler) {
+		h.limiter = &limiter
+	}
+}
+
+// limited answers 429 with Retry-After to callers whose bucket is empty,
+// and reports the caller's remaining budget in RateLimit-Limit,
+// RateLimit-Remaining and RateLimit-Reset headers. Requests are let through
+// when Redis cannot be reached, so the limiter never takes the API down.
+func (h SignalHandler) limited(next http.HandlerFunc) http.HandlerFunc {
+	if h.limiter == nil {
+		return next
+	}
+	return func(writer http.ResponseWriter, request *http.Request) {
+		decision, err := h.limiter.Allow(request.Context(), Identity(request.Context()), clientAddress(request))
+		if err != nil {
+			slog.WarnContext(request.Context(), "rate limit check failed, allowing request", "error", err)
+			next(writer, request)
+			return
+		}
+
+		headers := writer.Header()
+		headers.Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+		headers.Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		headers.Set("RateLimit-Reset", ceilSeconds(decision.Reset))
+		if !decision.Allowed {
+			headers.Set("Retry-After", ceilSeconds(decision.RetryAfter))
+			writeError(writer, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+		next(writer, request)
+	}
+}
+
+// mayAuthenticate answers 429 with Retry-After to client addresses whose
+// failed authentications emptied their bucket, before their credentials are
+// checked, so tokens cannot be guessed faster than the rate limit. Requests
+// are let through when Redis cannot be reached.
+func (h SignalHandler) mayAuthenticate(writer http.ResponseWriter, request *http.Request) bool {
+	if h.limiter == nil {
+		return true
+	}
+	decision, err := h.limiter.AllowAuthentication(request.Context(), clientAddress(request))
+	if err != nil {
+		slog.WarnContext(request.Context(), "authentication rate limit check failed, allowing request", "error", err)
+		return true
+	}
+	if !decision.Allowed {
+		writer.Header().Set("Retry-After", ceilSeconds(decision.RetryAfter))
+		writeError(writer, http.StatusTooManyRequests, "too many failed authentication attempts")
+		return false
+	}
+	return true
+}
+
+// countFailedAuthentication charges a failed authentication to the client
+// address.
+func (h SignalHandler) countFailedAuthentication(request *http.Request) {
+	if h.limiter == nil {
+		return
+	}
+	if err := h.limiter.FailAuthentication(request.Context(), clientAddress(request)); err != nil {
+		slog.WarnContext(request.Context(), "counting failed authentication failed", "error", err)
+	}
+}
+
+// clientAddress returns the IP the request came from. Proxy headers are not
+// trusted, as any client could set them to dodge its limit.
+func clientAddress(request *http.Request) string {
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		return request.RemoteAddr
+	}
+	return host
+}
+
+// ceilSeconds formats duration as whole seconds, rounded up.
+func ceilSeconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,138 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+func withLimit(t *testing.T, burst int) (handler.Option, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Logf("redis close error: %v", err)
+		}
+	})
+	limiter := ratelimit.New(client, ratelimit.Limit{Requests: 1, Period: time.Minute, Burst: burst})
+	return handler.WithRateLimiter(limiter), server
+}
+
+func serveFrom(mux *http.ServeMux, path, address string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	request.RemoteAddr = address
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestLimited_RefusesBeyondBurst(t *testing.T) {
+	option, _ := withLimit(t, 1)
+	mux, _ := setupHandler(t, option)
+
+	first := serveFrom(mux, "/signals", "10.0.0.1:5000")
+	second := serveFrom(mux, "/stats", "10.0.0.1:5001")
+
+	if first.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, first.Code)
+	}
+	if first.Header().Get("RateLimit-Limit") != "1" || first.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("expected rate limit headers, got %v", first.Header())
+	}
+	if second.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, second.Code)
+	}
+	if second.Header().Get("Retry-After") != "60" {
+		t.Errorf("expected Retry-After %q, got %q", "60", second.Header().Get("Retry-After"))
+	}
+	if second.Header().Get("RateLimit-Reset") != "60" {
+		t.Errorf("expected RateLimit-Reset %q, got %q", "60", second.Header().Get("RateLimit-Reset"))
+	}
+}
+
+func TestLimited_PerClient(t *testing.T) {
+	option, _ := withLimit(t, 1)
+	mux := setupAuthenticatedHandlerWith(t, option)
+	tests := []struct {
+		key     string
+		address string
+		status  int
+	}{
+		{key: readerKey, address: "10.0.0.1:5000", status: http.StatusOK},
+		{key: readerKey, address: "10.0.0.2:5000", status: http.StatusTooManyRequests},
+		{key: profileKey, address: "10.0.0.1:5000", status: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/signals", nil)
+		request.RemoteAddr = test.address
+		request.Header.Set("Authorization", "Bearer "+test.key)
+		recorder := httptest.NewRecorder()
+
+		mux.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.status {
+			t.Errorf("expected status %d for %s from %s, got %d", test.status, test.key, test.address, recorder.Code)
+		}
+	}
+}
+
+func TestLimited_FailedAuthentication(t *testing.T) {
+	option, _ := withLimit(t, 2)
+	mux := setupAuthenticatedHandlerWith(t, option)
+	tests := []struct {
+		key     string
+		address string
+		status  int
+	}{
+		{key: "guessed-1", address: "10.0.0.1:5000", status: http.StatusUnauthorized},
+		{key: "guessed-2", address: "10.0.0.1:5001", status: http.StatusUnauthorized},
+		// Refused before the key is checked, even a valid one.
+		{key: readerKey, address: "10.0.0.1:5002", status: http.StatusTooManyRequests},
+		{key: readerKey, address: "10.0.0.2:5000", status: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/signals", nil)
+		request.RemoteAddr = test.address
+		request.Header.Set("Authorization", "Bearer "+test.key)
+		recorder := httptest.NewRecorder()
+
+		mux.ServeHTTP(recorder, request)
+
+		if recorder.Code != test.status {
+			t.Errorf("expected status %d for %s from %s, got %d", test.status, test.key, test.address, recorder.Code)
+		}
+	}
+}
+
+func TestLimited_HealthNotLimited(t *testing.T) {
+	option, _ := withLimit(t, 1)
+	mux, _ := setupHandler(t, option)
+	serveFrom(mux, "/signals", "10.0.0.1:5000")
+
+	recorder := serveFrom(mux, "/health", "10.0.0.1:5000")
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
+
+func TestLimited_AllowsWhenRedisFails(t *testing.T) {
+	option, server := withLimit(t, 1)
+	mux, _ := setupHandler(t, option)
+	server.Close()
+
+	recorder := serveFrom(mux, "/signals", "10.0.0.1:5000")
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+}
@@ -5,49 +5,137 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/ratelimit"
 )
 
+// listLimit is how many of the newest signals an unfiltered list returns.
+const listLimit = 50
+
 // SignalHandler serves the read API for the signals materialized view.
 type SignalHandler struct {
-	projection projection.SignalProjection
+	projection         projection.SignalProjection
+	consistencyTimeout time.Duration
+	authenticator      *auth.Authenticator
+	limiter            *ratelimit.Limiter
+}
+
+// Option configures a SignalHandler.
+type Option func(*SignalHandler)
+
+// WithConsistencyTimeout bounds how long a request carrying a consistency
+// token waits for the projection to catch up.
+func WithConsistencyTimeout(timeout time.Duration) Option {
+	return func(h *SignalHandler) {
+		h.consistencyTimeout = timeout
+	}
 }
 
 // New creates a SignalHandler.
-func New(proj projection.SignalProjection) SignalHandler {
-	return SignalHandler{projection: proj}
+func New(proj projection.SignalProjection, options ...Option) SignalHandler {
+	handler := SignalHandler{
+		projection:         proj,
+		consistencyTimeout: defaultConsistencyTimeout,
+	}
+	for _, option := range options {
+		option(&handler)
+	}
+	return handler
+}
+
+// Route is a ServeMux pattern and the handler mounted on it.
+type Route struct {
+	Pattern string
+	Handler http.HandlerFunc
+}
+
+// Routes returns the routes Register mounts. Read routes are served under
+// /v1 and, deprecated, at their unversioned paths. The API documentation at
+// /openapi.json and /docs is open, like /health.
+func (h SignalHandler) Routes() []Route {
+	reads := []Route{
+		{"GET /signals", h.authenticated(h.limited(h.consistent(h.listSignals)))},
+		{"GET /signals/{id}", h.authenticated(h.limited(h.consistent(h.getSignal)))},
+		{"POST /signals/batch", h.authenticated(h.limited(h.consistent(h.postBatch)))},
+		{"GET /stats", h.authenticated(h.limited(h.consistent(h.stats)))},
+		{"GET /positions", h.authenticated(h.limited(h.positions))},
+	}
+	routes := make([]Route, 0, 2*len(reads)+3)
+	for _, route := range reads {
+		method, path, _ := strings.Cut(route.Pattern, " ")
+		routes = append(routes,
+			Route{method + " " + versionPrefix + path, route.Handler},
+			Route{route.Pattern, legacy(route.Handler)},
+		)
+	}
+	return append(routes,
+		Route{"GET /health", h.health},
+		Route{"GET /openapi.json", openAPI},
+		Route{"GET /docs", docs},
+	)
 }
 
 // Register mounts the handler routes on the given ServeMux.
 func (h SignalHandler) Register(mux *http.ServeMux) {
-	mux.HandleFunc("GET /signals", h.listSignals)
-	mux.HandleFunc("GET /signals/{id}", h.getSignal)
+	for _, route := range h.Routes() {
+		mux.HandleFunc(route.Pattern, route.Handler)
+	}
+}
+
+// RegisterHealth mounts only the health route, for instances that do not
+// serve reads.
+func (h SignalHandler) RegisterHealth(mux *http.ServeMux) {
 	mux.HandleFunc("GET /health", h.health)
 }
 
 func (h SignalHandler) listSignals(writer http.ResponseWriter, request *http.Request) {
 	query := request.URL.Query()
+	if query.Has("ids") {
+		h.batchSignals(writer, request)
+		return
+	}
+	fields, ok := requestedFields(writer, request)
+	if !ok {
+		return
+	}
 	priority := query.Get("priority")
-	signals, err := h.fetchSignals(request.Context(), priority)
+	signals, err := h.fetchSignals(request.Context(), clearance(request.Context()), priority, fields)
 	if err != nil {
 		writeError(writer, http.StatusInternalServerError, "failed to list signals")
 		return
 	}
-	writeJSON(writer, http.StatusOK, signals)
+	// Deleting a signal moves no updated_at, so lists are only validated by
+	// their ETag.
+	page := &domain.Page{Count: len(signals)}
+	if priority == "" {
+		page.Limit = listLimit
+	}
+	writeCacheable(writer, request, envelope(request, sparseSignals(signals, fields), page), lastUpdated(signals...), false)
 }
 
-func (h SignalHandler) fetchSignals(ctx context.Context, priority string) ([]domain.Signal, error) {
+func (h SignalHandler) fetchSignals(ctx context.Context, clearance, priority string, fields []string) ([]domain.Signal, error) {
+	proj := h.projection.Only(fields...)
 	if priority != "" {
-		return h.projection.ListByPriority(ctx, priority)
+		return proj.ListByPriority(ctx, clearance, priority)
 	}
-	return h.projection.ListByCreatedAt(ctx, 0, 49)
+	return proj.ListByCreatedAt(ctx, clearance, 0, listLimit-1)
 }
 
 func (h SignalHandler) getSignal(writer http.ResponseWriter, request *http.Request) {
+	fields, ok := requestedFields(writer, request)
+	if !ok {
+		return
+	}
 	id := request.PathValue("id")
-	signal, err := h.projection.FindByID(request.Context(), id)
+	signal, err := h.projection.Only(fields...).FindByID(request.Context(), id)
+	if err == nil && !domain.Visible(signal.Visibility, clearance(request.Context())) {
+		err = projection.ErrNotFound
+	}
 	if errors.Is(err, projection.ErrNotFound) {
 		writeError(writer, http.StatusNotFound, "signal not found")
 		return
@@ -56,7 +144,36 @@ func (h SignalHandler) getSignal(writer http.ResponseWriter, request *http.Reque
 		writeError(writer, http.StatusInternalServerError, "failed to get signal")
 		return
 	}
-	writeJSON(writer, http.StatusOK, signal)
+	writeCacheable(writer, request, envelope(request, sparseSignal(signal, fields), nil), lastUpdated(signal), true)
+}
+
+func (h SignalHandler) stats(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+	from, err := parseDay(query.Get("from"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, "invalid from date, expected YYYY-MM-DD")
+		return
+	}
+	to, err := parseDay(query.Get("to"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, "invalid to date, expected YYYY-MM-DD")
+		return
+	}
+	stats, err := h.projection.Stats(request.Context(), clearance(request.Context()), from, to)
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "failed to compute stats")
+		return
+	}
+	writeJSON(writer, http.StatusOK, envelope(request, stats, nil))
+}
+
+func (h SignalHandler) positions(writer http.ResponseWriter, request *http.Request) {
+	positions, err := h.projection.Positions(request.Context())
+	if err != nil {
+		writeError(writer, http.StatusInternalServerError, "failed to read positions")
+		return
+	}
+	writeJSON(writer, http.StatusOK, envelope(request, positions, nil))
 }
 
 func (h SignalHandler) health(writer http.ResponseWriter, request *http.Request) {
@@ -76,6 +193,21 @@ func writeJSON(writer http.ResponseWriter, status int, data interface{}) {
 	_ = encoder.Encode(data)
 }
 
+// writeError writes the error body, echoing the request ID set by
+// LogRequests so callers can quote it when reporting a failure.
 func writeError(writer http.ResponseWriter, status int, message string) {
-	writeJSON(writer, status, map[string]string{"error": message})
+	body := map[string]string{"error": message}
+	if id := writer.Header().Get(RequestIDHeader); id != "" {
+		body["request_id"] = id
+	}
+	writeJSON(writer, status, body)
+}
+
+// parseDay parses an optional YYYY-MM-DD query value. An empty value yields
+// the zero time, meaning unbounded.
+func parseDay(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
 }
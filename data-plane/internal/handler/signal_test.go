@@ -13,7 +13,7 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-func setupHandler(t *testing.T) (*http.ServeMux, projection.SignalProjection) {
+func setupHandler(t *testing.T, options ...handler.Option) (*http.ServeMux, projection.SignalProjection) {
 	t.Helper()
 	server := miniredis.RunT(t)
 	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
@@ -24,7 +24,7 @@ func setupHandler(t *testing.T) (*http.ServeMux, projection.SignalProjection) {
 	})
 
 	proj := projection.New(client)
-	signalHandler := handler.New(proj)
+	signalHandler := handler.New(proj, options...)
 	mux := http.NewServeMux()
 	signalHandler.Register(mux)
 
@@ -185,6 +185,28 @@ func TestHealth_OK(t *testing.T) {
 	}
 }
 
+func TestRegisterHealth_OnlyHealth(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Logf("redis close error: %v", err)
+		}
+	})
+	mux := http.NewServeMux()
+	handler.New(projection.New(client)).RegisterHealth(mux)
+
+	for path, expected := range map[string]int{"/health": http.StatusOK, "/signals": http.StatusNotFound} {
+		recorder := httptest.NewRecorder()
+
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if recorder.Code != expected {
+			t.Errorf("expected status %d for %s, got %d", expected, path, recorder.Code)
+		}
+	}
+}
+
 func TestListSignals_ContentTypeJSON(t *testing.T) {
 	mux, _ := setupHandler(t)
 
@@ -198,3 +220,70 @@ func TestListSignals_ContentTypeJSON(t *testing.T) {
 		t.Errorf("expected Content-Type %q, got %q", "application/json", contentType)
 	}
 }
+
+func TestStats_ReturnsCounts(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00Z")
+	seedSignal(t, proj, "s2", "Low", "2026-02-24T10:00:00Z")
+
+	request := httptest.NewRequest(http.MethodGet, "/stats?from=2026-02-24", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	var stats domain.Stats
+	if err := json.NewDecoder(recorder.Body).Decode(&stats); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if stats.Total != 1 {
+		t.Errorf("expected total 1, got %d", stats.Total)
+	}
+	if stats.ByPriority["Low"] != 1 {
+		t.Errorf("expected 1 Low signal, got %v", stats.ByPriority)
+	}
+}
+
+func TestStats_InvalidDate(t *testing.T) {
+	mux, _ := setupHandler(t)
+
+	request := httptest.NewRequest(http.MethodGet, "/stats?to=yesterday", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, recorder.Code)
+	}
+}
+
+func TestPositions_ReturnsAppliedOffsets(t *testing.T) {
+	mux, proj := setupHandler(t)
+	event := domain.SignalEvent{Action: domain.ActionCreated, ID: "s1", Priority: "High"}
+	position := domain.Position{Topic: "nexus.signals", Partition: 0, Offset: 12}
+	if err := proj.ApplyAt(t.Context(), event, position); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	request := httptest.NewRequest(http.MethodGet, "/positions", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, recorder.Code)
+	}
+
+	var positions []domain.Position
+	if err := json.NewDecoder(recorder.Body).Decode(&positions); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if len(positions) != 1 || positions[0] != position {
+		t.Errorf("expected positions [%v], got %v", position, positions)
+	}
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+
+type routeKey struct{}
+
+// routeSlot holds the pattern the mux matched. Trace installs it, since the
+// mux sets Pattern only on the request it receives, which middleware between
+// the two replaces with copies; the middleware next to the mux fills it in.
+type routeSlot struct {
+	pattern string
+}
+
+// recordRoute copies the pattern the mux matched for request into the slot
+// Trace installed, when there is one.
+func recordRoute(request *http.Request) {
+	if slot, _ := request.Context().Value(routeKey{}).(*routeSlot); slot != nil && request.Pattern != "" {
+		slot.pattern = request.Pattern
+	}
+}
+
+// Instrument wraps the server's mux with the middleware every request goes
+// through: tracing, request logging and client-certificate identification.
+func Instrument(mux http.Handler) http.Handler {
+	return Trace(LogRequests(IdentifyClientCertificates(mux)))
+}
+
+// Trace wraps next with a server span per request, continuing the caller's
+// trace when the request carries a traceparent header. Spans are named
+// after the matched route, e.g. "GET /signals/{id}".
+func Trace(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(request.Context(), propagation.HeaderCarrier(request.Header))
+		ctx, span := otel.Tracer(instrumentationName).Start(ctx, request.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", request.Method),
+				attribute.String("url.path", request.URL.Path),
+			),
+		)
+		defer span.End()
+
+		recorder := &statusRecorder{ResponseWriter: writer, status: http.StatusOK}
+		slot := &routeSlot{}
+		routed := request.WithContext(context.WithValue(ctx, routeKey{}, slot))
+		next.ServeHTTP(recorder, routed)
+
+		pattern := routed.Pattern
+		if pattern == "" {
+			pattern = slot.pattern
+		}
+		if pattern != "" {
+			span.SetName(pattern)
+			_, route, _ := strings.Cut(pattern, " ")
+			span.SetAttributes(attribute.String("http.route", route))
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", recorder.status))
+		if recorder.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(recorder.status))
+		}
+	})
+}
+
+// statusRecorder captures the status code written by a handler.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupTracing(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+func TestTrace_SpanPerRequest(t *testing.T) {
+	spans := setupTracing(t)
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00Z")
+	request := httptest.NewRequest(http.MethodGet, "/signals/s1", nil)
+	request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	recorder := httptest.NewRecorder()
+
+	handler.Trace(mux).ServeHTTP(recorder, request)
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(ended))
+	}
+	span := ended[0]
+	if span.Name() != "GET /signals/{id}" {
+		t.Errorf("expected span named after the route, got %q", span.Name())
+	}
+	if traceID := span.SpanContext().TraceID().String(); traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the caller's trace, got %s", traceID)
+	}
+	for _, attribute := range span.Attributes() {
+		if attribute.Key == "http.response.status_code" && attribute.Value.AsInt64() != http.StatusOK {
+			t.Errorf("expected status attribute %d, got %d", http.StatusOK, attribute.Value.AsInt64())
+		}
+	}
+}
+
+func TestInstrument_SpanNamedAfterRoute(t *testing.T) {
+	spans := setupTracing(t)
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00Z")
+	request := httptest.NewRequest(http.MethodGet, "/v1/signals/s1", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.Instrument(mux).ServeHTTP(recorder, request)
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(ended))
+	}
+	if name := ended[0].Name(); name != "GET /v1/signals/{id}" {
+		t.Errorf("expected span named after the route, got %q", name)
+	}
+	var route string
+	for _, attribute := range ended[0].Attributes() {
+		if attribute.Key == "http.route" {
+			route = attribute.Value.AsString()
+		}
+	}
+	if route != "/v1/signals/{id}" {
+		t.Errorf("expected http.route %q, got %q", "/v1/signals/{id}", route)
+	}
+}
+
+func TestTrace_UnmatchedRoute(t *testing.T) {
+	spans := setupTracing(t)
+	mux, _ := setupHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/unknown", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.Trace(mux).ServeHTTP(recorder, request)
+
+	ended := spans.Ended()
+	if len(ended) != 1 || ended[0].Name() != http.MethodGet {
+		t.Errorf("expected a single span named after the method, got %v", ended)
+	}
+}
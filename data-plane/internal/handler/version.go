@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+// versionPrefix is the path prefix of the current read API routes.
+const versionPrefix = "/" + domain.APIVersion
+
+// The unversioned routes are deprecated aliases of the /v1 ones, announced
+// in Deprecation and Sunset headers.
+var (
+	legacyDeprecation = time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	legacySunset      = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type legacyKey struct{}
+
+// legacy serves next on an unversioned route: bodies are written bare, as
+// before /v1, and responses carry Deprecation, Sunset and a Link to the
+// route's /v1 successor.
+func legacy(next http.HandlerFunc) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		headers := writer.Header()
+		headers.Set("Deprecation", "@"+strconv.FormatInt(legacyDeprecation.Unix(), 10))
+		headers.Set("Sunset", legacySunset.Format(http.TimeFormat))
+		headers.Add("Link", "<"+versionPrefix+request.URL.Path+`>; rel="successor-version"`)
+		next(writer, request.WithContext(context.WithValue(request.Context(), legacyKey{}, true)))
+	}
+}
+
+// envelope wraps data in the v1 envelope, or returns it bare on legacy
+// routes. Page is only set for lists.
+func envelope(request *http.Request, data any, page *domain.Page) any {
+	if isLegacy, _ := request.Context().Value(legacyKey{}).(bool); isLegacy {
+		return data
+	}
+	return domain.Envelope[any]{
+		Data: data,
+		Page: page,
+		Meta: domain.Meta{Version: domain.APIVersion},
+	}
+}
@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func TestV1_ListSignalsEnvelope(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	seedSignal(t, proj, "s2", "Low", "2026-02-23T16:00:00-03:00")
+	request := httptest.NewRequest(http.MethodGet, "/v1/signals", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	var body domain.Envelope[[]domain.Signal]
+	decodeSparse(t, recorder, &body)
+	if len(body.Data) != 2 || body.Data[0].ID != "s2" {
+		t.Errorf("expected s2 then s1, got %+v", body.Data)
+	}
+	if body.Page == nil || body.Page.Count != 2 || body.Page.Limit != 50 {
+		t.Errorf("expected a page of 2 limited to 50, got %+v", body.Page)
+	}
+	if body.Meta.Version != "v1" {
+		t.Errorf("expected version %q, got %q", "v1", body.Meta.Version)
+	}
+	if recorder.Header().Get("Deprecation") != "" {
+		t.Errorf("expected no Deprecation header on a v1 route")
+	}
+}
+
+func TestV1_PriorityListIsComplete(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	request := httptest.NewRequest(http.MethodGet, "/v1/signals?priority=High", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	var body domain.Envelope[[]domain.Signal]
+	decodeSparse(t, recorder, &body)
+	if body.Page == nil || body.Page.Count != 1 || body.Page.Limit != 0 {
+		t.Errorf("expected a complete page of 1, got %+v", body.Page)
+	}
+}
+
+func TestV1_ResourcesHaveNoPage(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	targets := []string{"/v1/signals/s1", "/v1/signals?ids=s1", "/v1/stats", "/v1/positions"}
+
+	for _, target := range targets {
+		t.Run(target, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, target, nil)
+			recorder := httptest.NewRecorder()
+
+			mux.ServeHTTP(recorder, request)
+
+			var body map[string]json.RawMessage
+			decodeSparse(t, recorder, &body)
+			if body["data"] == nil || body["meta"] == nil {
+				t.Errorf("expected data and meta, got %v", body)
+			}
+			if body["page"] != nil {
+				t.Errorf("expected no page, got %s", body["page"])
+			}
+		})
+	}
+}
+
+func TestV1_PostBatchEnvelope(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	request := httptest.NewRequest(http.MethodPost, "/v1/signals/batch", strings.NewReader(`{"ids": ["s1", "missing"]}`))
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	var body domain.Envelope[[]domain.BatchItem]
+	decodeSparse(t, recorder, &body)
+	assertBatch(t, body.Data, map[string]bool{"s1": true}, []string{"s1", "missing"})
+}
+
+func TestLegacy_AnnouncesDeprecation(t *testing.T) {
+	mux, proj := setupHandler(t)
+	seedSignal(t, proj, "s1", "High", "2026-02-23T15:00:00-03:00")
+	request := httptest.NewRequest(http.MethodGet, "/signals/s1", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	var signal domain.Signal
+	decodeSparse(t, recorder, &signal)
+	if signal.ID != "s1" {
+		t.Errorf("expected the bare signal, got %+v", signal)
+	}
+	headers := recorder.Header()
+	if !strings.HasPrefix(headers.Get("Deprecation"), "@") {
+		t.Errorf("expected a Deprecation date, got %q", headers.Get("Deprecation"))
+	}
+	if _, err := http.ParseTime(headers.Get("Sunset")); err != nil {
+		t.Errorf("expected an HTTP date in Sunset, got %q", headers.Get("Sunset"))
+	}
+	expectedLink := `</v1/signals/s1>; rel="successor-version"`
+	if headers.Get("Link") != expectedLink {
+		t.Errorf("expected Link %q, got %q", expectedLink, headers.Get("Link"))
+	}
+}
+
+func TestHealth_NotVersioned(t *testing.T) {
+	mux, _ := setupHandler(t)
+	request := httptest.NewRequest(http.MethodGet, "/health", nil)
+	recorder := httptest.NewRecorder()
+
+	mux.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get("Deprecation") != "" {
+		t.Errorf("expected no Deprecation header on /health")
+	}
+}
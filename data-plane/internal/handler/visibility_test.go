@@ -0,0 +1,141 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/auth"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/handler"
+)
+
+const (
+	internalKey = "nxs_internal"
+	adminKey    = "nxs_admin"
+)
+
+// setupVisibilityHandler seeds one signal per visibility level, with ids
+// named after their level.
+func setupVisibilityHandler(t *testing.T, options ...handler.Option) *http.ServeMux {
+	t.Helper()
+	mux, proj := setupHandler(t, options...)
+	for _, visibility := range domain.Visibilities() {
+		event := domain.SignalEvent{
+			Action:     domain.ActionCreated,
+			ID:         visibility,
+			Title:      "Signal " + visibility,
+			Priority:   "High",
+			Author:     "otavio",
+			CreatedAt:  "2026-02-23T15:00:00-03:00",
+			UpdatedAt:  "2026-02-23T15:00:00-03:00",
+			Visibility: visibility,
+		}
+		if err := proj.Apply(t.Context(), event); err != nil {
+			t.Fatalf("failed to seed signal %s: %v", visibility, err)
+		}
+	}
+	return mux
+}
+
+func withRoles(t *testing.T) handler.Option {
+	t.Helper()
+	authenticator, err := auth.New([]auth.APIKey{
+		{Name: "dashboard", SHA256: auth.HashKey(readerKey)},
+		{Name: "operations", SHA256: auth.HashKey(internalKey), Role: domain.VisibilityInternal},
+		{Name: "audit", SHA256: auth.HashKey(adminKey), Role: domain.VisibilityAdmin},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return handler.WithAuthenticator(authenticator)
+}
+
+func serve(mux *http.ServeMux, path, key string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	if key != "" {
+		request.Header.Set("Authorization", "Bearer "+key)
+	}
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestListSignals_FiltersByRole(t *testing.T) {
+	mux := setupVisibilityHandler(t, withRoles(t))
+	tests := map[string]struct {
+		key      string
+		expected int
+	}{
+		"no role":  {key: readerKey, expected: 1},
+		"internal": {key: internalKey, expected: 2},
+		"admin":    {key: adminKey, expected: 3},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := serve(mux, "/signals", test.key)
+
+			var signals []domain.Signal
+			if err := json.NewDecoder(recorder.Body).Decode(&signals); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(signals) != test.expected {
+				t.Errorf("expected %d signals, got %d", test.expected, len(signals))
+			}
+		})
+	}
+}
+
+func TestGetSignal_HidesRestrictedSignals(t *testing.T) {
+	mux := setupVisibilityHandler(t, withRoles(t))
+	tests := map[string]struct {
+		path   string
+		key    string
+		status int
+	}{
+		"admin signal to internal": {path: "/signals/admin", key: internalKey, status: http.StatusNotFound},
+		"admin signal to admin":    {path: "/signals/admin", key: adminKey, status: http.StatusOK},
+		"internal signal to none":  {path: "/signals/internal", key: readerKey, status: http.StatusNotFound},
+		"public signal to none":    {path: "/signals/public", key: readerKey, status: http.StatusOK},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := serve(mux, test.path, test.key)
+
+			if recorder.Code != test.status {
+				t.Errorf("expected status %d, got %d", test.status, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestStats_ScopedByRole(t *testing.T) {
+	mux := setupVisibilityHandler(t, withRoles(t))
+
+	recorder := serve(mux, "/stats", internalKey)
+
+	var stats domain.Stats
+	if err := json.NewDecoder(recorder.Body).Decode(&stats); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if stats.Total != 2 {
+		t.Errorf("expected total 2, got %d", stats.Total)
+	}
+}
+
+func TestListSignals_AnonymousSeesPublicOnly(t *testing.T) {
+	mux := setupVisibilityHandler(t)
+
+	recorder := serve(mux, "/signals?priority=High", "")
+
+	var signals []domain.Signal
+	if err := json.NewDecoder(recorder.Body).Decode(&signals); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(signals) != 1 || signals[0].ID != domain.VisibilityPublic {
+		t.Errorf("expected only the public signal, got %v", signals)
+	}
+}
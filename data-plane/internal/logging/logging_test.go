@@ -0,0 +1,69 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/logging"
+)
+
+func TestSetup_JSONFormat(t *testing.T) {
+	var output bytes.Buffer
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	logger, err := logging.Setup(&output, "warn", "json")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	logger.Info("dropped below the level")
+	slog.Warn("projection failed", "signal_id", "s1", "offset", 41)
+
+	var entry map[string]any
+	if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a single JSON entry, got %q: %v", output.String(), err)
+	}
+	if entry["msg"] != "projection failed" || entry["signal_id"] != "s1" || entry["offset"] != float64(41) {
+		t.Errorf("expected structured fields on the default logger, got %v", entry)
+	}
+}
+
+func TestSetup_InvalidOptions(t *testing.T) {
+	tests := map[string][2]string{
+		"unknown level":  {"verbose", "text"},
+		"unknown format": {"info", "logfmt"},
+	}
+
+	for name, options := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := logging.Setup(&bytes.Buffer{}, options[0], options[1])
+
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"":      slog.LevelInfo,
+		"debug": slog.LevelDebug,
+		"WARN":  slog.LevelWarn,
+		"error": slog.LevelError,
+	}
+
+	for value, expected := range tests {
+		level, err := logging.ParseLevel(value)
+
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", value, err)
+		}
+		if level != expected {
+			t.Errorf("expected %v for %q, got %v", expected, value, level)
+		}
+	}
+}
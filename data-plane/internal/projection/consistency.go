@@ -0,0 +1,50 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// Reflects reports whether the view has caught up with token: the token's
+// partition offset has been applied, or the token's signal is stored with
+// an updated_at at or after the token's, or is gone for a deletion.
+func (p SignalProjection) Reflects(ctx context.Context, token domain.ConsistencyToken) (bool, error) {
+	if token.IsSignal() {
+		return p.reflectsWrite(ctx, token)
+	}
+
+	positions, err := p.Positions(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, position := range positions {
+		if position.Partition == token.Partition && position.Offset >= token.Offset {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reflectsWrite compares the token with its own signal only, so writes to
+// other signals, on any partition, cannot satisfy it.
+func (p SignalProjection) reflectsWrite(ctx context.Context, token domain.ConsistencyToken) (bool, error) {
+	value, err := p.client.HGet(ctx, signalKey(token.SignalID), "updated_at").Result()
+	if errors.Is(err, redis.Nil) {
+		return token.Deleted, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if token.Deleted {
+		return false, nil
+	}
+	updatedAt, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return false, nil
+	}
+	return !updatedAt.Before(token.UpdatedAt), nil
+}
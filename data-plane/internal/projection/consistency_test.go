@@ -0,0 +1,121 @@
+package projection_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func mustToken(t *testing.T, value string) domain.ConsistencyToken {
+	t.Helper()
+	token, err := domain.ParseConsistencyToken(value)
+	if err != nil {
+		t.Fatalf("invalid token %q: %v", value, err)
+	}
+	return token
+}
+
+func TestReflects_Offset(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	if err := proj.ApplyAt(ctx, sampleEvent(domain.ActionCreated, "signal-1"), at(0, 9)); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	cases := map[string]bool{"0:8": true, "0:9": true, "0:10": false, "1:0": false}
+	for value, expected := range cases {
+		reached, err := proj.Reflects(ctx, mustToken(t, value))
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", value, err)
+		}
+		if reached != expected {
+			t.Errorf("token %q: expected %v, got %v", value, expected, reached)
+		}
+	}
+}
+
+func TestReflects_SignalUpdate(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-1")); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	cases := map[string]bool{
+		"signal-1@2026-02-23T18:05:00Z":      true,
+		"signal-1@2026-02-23T15:04:59-03:00": true,
+		"signal-1@2026-02-23T15:05:01-03:00": false,
+		"signal-2@2026-02-20T10:00:00-03:00": false,
+		"signal-1@deleted":                   false,
+		"signal-2@deleted":                   true,
+	}
+	for value, expected := range cases {
+		reached, err := proj.Reflects(ctx, mustToken(t, value))
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", value, err)
+		}
+		if reached != expected {
+			t.Errorf("token %q: expected %v, got %v", value, expected, reached)
+		}
+	}
+}
+
+func TestReflects_SignalDeleted(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-1")); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+	token := mustToken(t, "signal-1@deleted")
+
+	before, err := proj.Reflects(ctx, token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionDeleted, "signal-1")); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+	after, err := proj.Reflects(ctx, token)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if before || !after {
+		t.Errorf("expected the delete to be reflected only once applied, got %v then %v", before, after)
+	}
+}
+
+func TestReflects_IgnoresOtherPartitions(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	// The caller's write lands on partition 0; a newer, unrelated write on
+	// partition 1 is applied first.
+	written := sampleEvent(domain.ActionUpdated, "signal-1")
+	written.UpdatedAt = "2026-02-23T15:05:00-03:00"
+	unrelated := sampleEvent(domain.ActionCreated, "signal-2")
+	unrelated.UpdatedAt = "2026-02-23T16:00:00-03:00"
+	if err := proj.ApplyAt(ctx, unrelated, at(1, 40)); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+	token := mustToken(t, "signal-1@"+written.UpdatedAt)
+
+	before, err := proj.Reflects(ctx, token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := proj.ApplyAt(ctx, written, at(0, 7)); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+	after, err := proj.Reflects(ctx, token)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if before {
+		t.Error("expected a newer write on another partition not to reflect the token")
+	}
+	if !after {
+		t.Error("expected the token to be reflected once its own write is applied")
+	}
+}
@@ -0,0 +1,121 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const positionPrefix = "signals:position:"
+
+// ErrAlreadyApplied is returned by ApplyAt when the view already reflects
+// the given position, meaning the event is being redelivered.
+var ErrAlreadyApplied = errors.New("position already applied")
+
+// Advance records position without applying an event, for messages that
+// are skipped. Positions at or behind the stored one are ignored.
+func (p SignalProjection) Advance(ctx context.Context, position domain.Position) error {
+	key := positionKey(position.Topic, position.Partition)
+	err := p.client.Watch(ctx, func(tx *redis.Tx) error {
+		if err := checkNotApplied(ctx, tx, position); err != nil {
+			return err
+		}
+		_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			queuePosition(ctx, pipe, position)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, ErrAlreadyApplied) {
+		return nil
+	}
+	return err
+}
+
+// Positions returns the last applied position of every partition the view
+// has recorded, ordered by topic and partition.
+func (p SignalProjection) Positions(ctx context.Context) ([]domain.Position, error) {
+	positions := []domain.Position{}
+	iterator := p.client.Scan(ctx, 0, positionPrefix+"*", scanCount).Iterator()
+	for iterator.Next(ctx) {
+		key := iterator.Val()
+		offset, err := p.client.Get(ctx, key).Int64()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		position, err := parsePositionKey(key)
+		if err != nil {
+			return nil, err
+		}
+		position.Offset = offset
+		positions = append(positions, position)
+	}
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].Topic != positions[j].Topic {
+			return positions[i].Topic < positions[j].Topic
+		}
+		return positions[i].Partition < positions[j].Partition
+	})
+	return positions, nil
+}
+
+// RestorePositions records positions as the last applied ones, overwriting
+// what is stored. Used when importing a snapshot.
+func (p SignalProjection) RestorePositions(ctx context.Context, positions []domain.Position) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	pipe := p.client.Pipeline()
+	for _, position := range positions {
+		queuePosition(ctx, pipe, position)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func checkNotApplied(ctx context.Context, tx *redis.Tx, position domain.Position) error {
+	stored, err := tx.Get(ctx, positionKey(position.Topic, position.Partition)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if position.Offset <= stored {
+		return ErrAlreadyApplied
+	}
+	return nil
+}
+
+func queuePosition(ctx context.Context, pipe redis.Pipeliner, position domain.Position) {
+	pipe.Set(ctx, positionKey(position.Topic, position.Partition), position.Offset, 0)
+}
+
+func positionKey(topic string, partition int) string {
+	return positionPrefix + topic + ":" + strconv.Itoa(partition)
+}
+
+func parsePositionKey(key string) (domain.Position, error) {
+	name := strings.TrimPrefix(key, positionPrefix)
+	separator := strings.LastIndex(name, ":")
+	if separator < 0 {
+		return domain.Position{}, fmt.Errorf("malformed position key %q", key)
+	}
+	partition, err := strconv.Atoi(name[separator+1:])
+	if err != nil {
+		return domain.Position{}, fmt.Errorf("malformed position key %q: %w", key, err)
+	}
+	return domain.Position{Topic: name[:separator], Partition: partition}, nil
+}
@@ -0,0 +1,96 @@
+package projection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+)
+
+func at(partition int, offset int64) domain.Position {
+	return domain.Position{Topic: "nexus.signals", Partition: partition, Offset: offset}
+}
+
+func TestApplyAt_RecordsPosition(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	err := proj.ApplyAt(ctx, sampleEvent(domain.ActionCreated, "signal-1"), at(0, 7))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	positions, err := proj.Positions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(positions) != 1 || positions[0] != at(0, 7) {
+		t.Errorf("expected position %v, got %v", at(0, 7), positions)
+	}
+}
+
+func TestApplyAt_DetectsReapplication(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	if err := proj.ApplyAt(ctx, sampleEvent(domain.ActionCreated, "signal-1"), at(0, 7)); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	stale := sampleEvent(domain.ActionUpdated, "signal-1")
+	stale.Title = "Redelivered"
+	err := proj.ApplyAt(ctx, stale, at(0, 7))
+
+	if !errors.Is(err, projection.ErrAlreadyApplied) {
+		t.Fatalf("expected ErrAlreadyApplied, got %v", err)
+	}
+	signal, err := proj.FindByID(ctx, "signal-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signal.Title != "Server Alert" {
+		t.Errorf("expected redelivered event to be ignored, got title %q", signal.Title)
+	}
+}
+
+func TestApplyAt_PartitionsAreIndependent(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	if err := proj.ApplyAt(ctx, sampleEvent(domain.ActionCreated, "signal-1"), at(1, 50)); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+	err := proj.ApplyAt(ctx, sampleEvent(domain.ActionCreated, "signal-2"), at(0, 3))
+
+	if err != nil {
+		t.Fatalf("expected lower offset on another partition to apply, got %v", err)
+	}
+	positions, err := proj.Positions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(positions) != 2 || positions[0] != at(0, 3) || positions[1] != at(1, 50) {
+		t.Errorf("unexpected positions: %v", positions)
+	}
+}
+
+func TestAdvance_IgnoresOlderPositions(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	if err := proj.Advance(ctx, at(0, 10)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := proj.Advance(ctx, at(0, 4)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	positions, err := proj.Positions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(positions) != 1 || positions[0].Offset != 10 {
+		t.Errorf("expected position to stay at offset 10, got %v", positions)
+	}
+}
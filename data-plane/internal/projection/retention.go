@@ -0,0 +1,171 @@
+package projection
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// releaseScript deletes the lock only if it is still held by the caller's token.
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// refreshScript extends the lock's expiry only if it is still held by the
+// caller's token.
+var refreshScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// ErrLockNotHeld is returned when releasing or refreshing a lock that
+// expired or was taken over.
+var ErrLockNotHeld = errors.New("lock not held")
+
+// Lock is a Redis lock held by a single instance until released or expired.
+type Lock struct {
+	client *redis.Client
+	key    string
+	token  string
+}
+
+// TryLock attempts to acquire the named lock for ttl. Returns false when
+// another holder already owns it.
+func (p SignalProjection) TryLock(ctx context.Context, name string, ttl time.Duration) (Lock, bool, error) {
+	token, err := randomToken()
+	if err != nil {
+		return Lock{}, false, err
+	}
+	key := lockKey(name)
+	acquired, err := p.client.SetNX(ctx, key, token, ttl).Result()
+	if err != nil || !acquired {
+		return Lock{}, false, err
+	}
+	return Lock{client: p.client, key: key, token: token}, true, nil
+}
+
+// Refresh extends the lock to expire ttl from now if it is still held by
+// this owner.
+func (l Lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	refreshed, err := refreshScript.Run(ctx, l.client, []string{l.key}, l.token, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if refreshed == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// Release frees the lock if it is still held by this owner.
+func (l Lock) Release(ctx context.Context) error {
+	deleted, err := releaseScript.Run(ctx, l.client, []string{l.key}, l.token).Int()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// ExpireBefore evicts every signal created before cutoff and returns how
+// many were removed. Signals without a parseable created_at are indexed at
+// score 0 and kept, since their age is unknown.
+func (p SignalProjection) ExpireBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	ids, err := p.client.ZRangeArgs(ctx, redis.ZRangeArgs{
+		Key:     keyByCreatedAt,
+		Start:   "(0",
+		Stop:    "(" + strconv.FormatInt(cutoff.Unix(), 10),
+		ByScore: true,
+	}).Result()
+	if err != nil {
+		return 0, err
+	}
+	return p.evictAll(ctx, ids)
+}
+
+// TrimPriority keeps only the newest keep signals of the given priority,
+// evicting the rest. Like ExpireBefore, it keeps the signals without a
+// parseable created_at, whose age is unknown, and does not count them
+// towards keep. Returns how many were removed.
+func (p SignalProjection) TrimPriority(ctx context.Context, priority string, keep int) (int, error) {
+	score := strconv.FormatFloat(priorityScore(priority), 'g', -1, 64)
+	ids, err := p.client.ZRangeArgs(ctx, redis.ZRangeArgs{
+		Key:     keyByPriority,
+		Start:   score,
+		Stop:    score,
+		ByScore: true,
+	}).Result()
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) <= keep {
+		return 0, nil
+	}
+	newestFirst, err := p.datedNewestFirst(ctx, ids)
+	if err != nil || len(newestFirst) <= keep {
+		return 0, err
+	}
+	return p.evictAll(ctx, newestFirst[keep:])
+}
+
+// datedNewestFirst sorts ids by creation, newest first, leaving out the
+// undated signals indexed at score 0.
+func (p SignalProjection) datedNewestFirst(ctx context.Context, ids []string) ([]string, error) {
+	pipe := p.client.Pipeline()
+	commands := make([]*redis.FloatCmd, len(ids))
+	for index, id := range ids {
+		commands[index] = pipe.ZScore(ctx, keyByCreatedAt, id)
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	scores := make(map[string]float64, len(ids))
+	sorted := make([]string, 0, len(ids))
+	for index, id := range ids {
+		if score := commands[index].Val(); score != 0 {
+			scores[id] = score
+			sorted = append(sorted, id)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return scores[sorted[i]] > scores[sorted[j]]
+	})
+	return sorted, nil
+}
+
+// evictAll removes each signal through evict so the stats counters and
+// every index stay consistent with the hashes.
+func (p SignalProjection) evictAll(ctx context.Context, ids []string) (int, error) {
+	for index, id := range ids {
+		if err := p.evict(ctx, id); err != nil {
+			return index, err
+		}
+	}
+	return len(ids), nil
+}
+
+func lockKey(name string) string {
+	return "signals:lock:" + name
+}
+
+func randomToken() (string, error) {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buffer), nil
+}
@@ -0,0 +1,216 @@
+package projection_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+)
+
+func TestTryLock_Exclusive(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	lock, acquired, err := proj.TryLock(ctx, "sweep", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("expected first lock to be acquired, got %v, %v", acquired, err)
+	}
+
+	_, acquired, err = proj.TryLock(ctx, "sweep", time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if acquired {
+		t.Fatal("expected second lock attempt to fail while held")
+	}
+
+	if err := lock.Release(ctx); err != nil {
+		t.Fatalf("unexpected release error: %v", err)
+	}
+	_, acquired, err = proj.TryLock(ctx, "sweep", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("expected lock to be acquired after release, got %v, %v", acquired, err)
+	}
+}
+
+func TestLockRelease_Expired(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+
+	lock, _, err := proj.TryLock(ctx, "sweep", time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server.FastForward(2 * time.Second)
+
+	err = lock.Release(ctx)
+
+	if !errors.Is(err, projection.ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld, got %v", err)
+	}
+}
+
+func TestLockRefresh_ExtendsExpiry(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+	lock, _, err := proj.TryLock(ctx, "sweep", time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = lock.Refresh(ctx, time.Minute)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server.FastForward(2 * time.Second)
+	if err := lock.Release(ctx); err != nil {
+		t.Errorf("expected the refreshed lock to still be held, got %v", err)
+	}
+}
+
+func TestLockRefresh_TakenOver(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+	lock, _, err := proj.TryLock(ctx, "sweep", time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	server.FastForward(2 * time.Second)
+	if _, acquired, err := proj.TryLock(ctx, "sweep", time.Minute); err != nil || !acquired {
+		t.Fatalf("expected the expired lock to be taken over, got %v, %v", acquired, err)
+	}
+
+	err = lock.Refresh(ctx, time.Minute)
+
+	if !errors.Is(err, projection.ErrLockNotHeld) {
+		t.Errorf("expected ErrLockNotHeld, got %v", err)
+	}
+}
+
+func TestExpireBefore_RemovesOldSignalsEverywhere(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+
+	old := sampleEvent(domain.ActionCreated, "old")
+	old.CreatedAt = "2026-01-01T10:00:00Z"
+	recent := sampleEvent(domain.ActionCreated, "recent")
+	recent.CreatedAt = "2026-02-20T10:00:00Z"
+	for _, event := range []domain.SignalEvent{old, recent} {
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply event: %v", err)
+		}
+	}
+
+	expired, err := proj.ExpireBefore(ctx, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expected 1 expired signal, got %d", expired)
+	}
+	if server.Exists("signal:old") {
+		t.Error("expected hash of expired signal to be removed")
+	}
+	if members, _ := server.ZMembers("signals:by_priority"); len(members) != 1 {
+		t.Errorf("expected priority index to hold 1 signal, got %v", members)
+	}
+	stats, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 1 {
+		t.Errorf("expected stats total 1, got %d", stats.Total)
+	}
+}
+
+func TestExpireBefore_KeepsUndatedSignals(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+	undated := sampleEvent(domain.ActionCreated, "undated")
+	undated.CreatedAt = "not a timestamp"
+	if err := proj.Apply(ctx, undated); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	expired, err := proj.ExpireBefore(ctx, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired != 0 {
+		t.Errorf("expected no expired signals, got %d", expired)
+	}
+	if !server.Exists("signal:undated") {
+		t.Error("expected undated signal to be kept")
+	}
+}
+
+func TestTrimPriority_KeepsNewest(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	days := []string{"2026-02-01", "2026-02-03", "2026-02-02"}
+	for index, day := range days {
+		event := sampleEvent(domain.ActionCreated, "high-"+day)
+		event.CreatedAt = day + "T10:00:00Z"
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply event %d: %v", index, err)
+		}
+	}
+	low := sampleEvent(domain.ActionCreated, "low-1")
+	low.Priority = "Low"
+	if err := proj.Apply(ctx, low); err != nil {
+		t.Fatalf("failed to apply low event: %v", err)
+	}
+
+	trimmed, err := proj.TrimPriority(ctx, "High", 2)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trimmed != 1 {
+		t.Errorf("expected 1 trimmed signal, got %d", trimmed)
+	}
+	if _, err := proj.FindByID(ctx, "high-2026-02-01"); err != projection.ErrNotFound {
+		t.Errorf("expected oldest high signal to be trimmed, got %v", err)
+	}
+	signals, err := proj.ListByPriority(ctx, domain.VisibilityAdmin, "Low")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(signals) != 1 {
+		t.Errorf("expected other priorities untouched, got %d Low signals", len(signals))
+	}
+}
+
+func TestTrimPriority_KeepsUndatedSignals(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	for _, createdAt := range []string{"not a timestamp", "2026-02-01T10:00:00Z", "2026-02-02T10:00:00Z"} {
+		event := sampleEvent(domain.ActionCreated, "high-"+createdAt)
+		event.CreatedAt = createdAt
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply event: %v", err)
+		}
+	}
+
+	trimmed, err := proj.TrimPriority(ctx, "High", 1)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trimmed != 1 {
+		t.Errorf("expected 1 trimmed signal, got %d", trimmed)
+	}
+	if _, err := proj.FindByID(ctx, "high-not a timestamp"); err != nil {
+		t.Errorf("expected the undated signal to be kept, got %v", err)
+	}
+	if _, err := proj.FindByID(ctx, "high-2026-02-01T10:00:00Z"); err != projection.ErrNotFound {
+		t.Errorf("expected the oldest dated signal to be trimmed, got %v", err)
+	}
+}
@@ -4,29 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
 	"github.com/redis/go-redis/v9"
 )
 
+// keyByCreatedAt and keyByPriority index every signal and serve admin
+// reads; indexKey derives the indexes scoped to less restricted clearances.
 const (
 	keyByCreatedAt = "signals:by_created_at"
 	keyByPriority  = "signals:by_priority"
 )
 
+// scopedClearances have their own indexes and stats counters, holding only
+// the signals visible at that clearance.
+var scopedClearances = []string{domain.VisibilityPublic, domain.VisibilityInternal}
+
 // ErrNotFound is returned when a signal does not exist in the projection.
 var ErrNotFound = errors.New("signal not found")
 
-var priorityScores = map[string]float64{
-	"Low":    1,
-	"Medium": 2,
-	"High":   3,
+// priorityScore orders signals in the priority index by their rank in
+// domain.Priorities. Unknown priorities score 0.
+func priorityScore(priority string) float64 {
+	return float64(domain.PriorityRank(priority))
 }
 
 // SignalProjection manages the Redis materialized view of signals.
 type SignalProjection struct {
 	client *redis.Client
+	// fields restricts the hash fields signal reads fetch; nil fetches all.
+	fields []string
 }
 
 // New creates a SignalProjection backed by the given Redis client.
@@ -34,42 +43,109 @@ func New(client *redis.Client) SignalProjection {
 	return SignalProjection{client: client}
 }
 
+// alwaysFetched are the fields Only adds to every read: reads need the id
+// to tell missing signals apart, callers the visibility to filter them and
+// updated_at to validate them.
+var alwaysFetched = []string{"id", "visibility", "updated_at"}
+
+// Only returns a copy of the projection whose signal reads fetch just the
+// given hash fields, plus id, visibility and updated_at, with HMGET instead
+// of HGETALL. Other fields of the signals read come back empty. No fields
+// fetches every field again.
+func (p SignalProjection) Only(fields ...string) SignalProjection {
+	p.fields = nil
+	if len(fields) > 0 {
+		p.fields = append(slices.Clone(alwaysFetched), fields...)
+	}
+	return p
+}
+
 // Apply processes a signal event and updates the materialized view.
 func (p SignalProjection) Apply(ctx context.Context, event domain.SignalEvent) error {
-	if event.Action == domain.ActionDeleted {
-		return p.evict(ctx, event.ID)
+	return p.apply(ctx, event, nil)
+}
+
+// ApplyAt applies an event read from position and records that position in
+// the same transaction, so the view and its stored position never diverge.
+// Returns ErrAlreadyApplied when the position was applied before.
+func (p SignalProjection) ApplyAt(ctx context.Context, event domain.SignalEvent, position domain.Position) error {
+	return p.apply(ctx, event, &position)
+}
+
+// apply upserts or evicts the signal, moving its stats counters out of the
+// previously stored bucket. The signal key (and position key, if any) is
+// watched so a concurrent write aborts the transaction instead of skewing
+// the counters or the recorded position.
+func (p SignalProjection) apply(ctx context.Context, event domain.SignalEvent, position *domain.Position) error {
+	keys := []string{signalKey(event.ID)}
+	if position != nil {
+		keys = append(keys, positionKey(position.Topic, position.Partition))
 	}
-	return p.upsert(ctx, event)
+	return p.client.Watch(ctx, func(tx *redis.Tx) error {
+		if position != nil {
+			if err := checkNotApplied(ctx, tx, *position); err != nil {
+				return err
+			}
+		}
+		previous, exists, err := storedBucket(ctx, tx, event.ID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if exists {
+				previous.add(ctx, pipe, -1)
+			}
+			if event.Action == domain.ActionDeleted {
+				queueEvict(ctx, pipe, event.ID)
+			} else {
+				queueUpsert(ctx, pipe, event)
+			}
+			if position != nil {
+				queuePosition(ctx, pipe, *position)
+			}
+			return nil
+		})
+		return err
+	}, keys...)
 }
 
-func (p SignalProjection) upsert(ctx context.Context, event domain.SignalEvent) error {
-	pipe := p.client.TxPipeline()
-	pipe.HSet(ctx, signalKey(event.ID), event.Fields())
-	pipe.ZAdd(ctx, keyByCreatedAt, redis.Z{
-		Score:  parseTimestamp(event.CreatedAt),
-		Member: event.ID,
-	})
-	pipe.ZAdd(ctx, keyByPriority, redis.Z{
-		Score:  priorityScores[event.Priority],
-		Member: event.ID,
-	})
-	_, err := pipe.Exec(ctx)
-	return err
+func queueUpsert(ctx context.Context, pipe redis.Pipeliner, event domain.SignalEvent) {
+	fields := event.Fields()
+	pipe.HSet(ctx, signalKey(event.ID), fields)
+	byCreatedAt := redis.Z{Score: parseTimestamp(event.CreatedAt), Member: event.ID}
+	byPriority := redis.Z{Score: priorityScore(event.Priority), Member: event.ID}
+	pipe.ZAdd(ctx, keyByCreatedAt, byCreatedAt)
+	pipe.ZAdd(ctx, keyByPriority, byPriority)
+	for _, clearance := range scopedClearances {
+		if domain.Visible(fields["visibility"], clearance) {
+			pipe.ZAdd(ctx, indexKey(keyByCreatedAt, clearance), byCreatedAt)
+			pipe.ZAdd(ctx, indexKey(keyByPriority, clearance), byPriority)
+		} else {
+			pipe.ZRem(ctx, indexKey(keyByCreatedAt, clearance), event.ID)
+			pipe.ZRem(ctx, indexKey(keyByPriority, clearance), event.ID)
+		}
+	}
+	bucketFromFields(fields).add(ctx, pipe, 1)
 }
 
-func (p SignalProjection) evict(ctx context.Context, id string) error {
-	pipe := p.client.TxPipeline()
+func queueEvict(ctx context.Context, pipe redis.Pipeliner, id string) {
 	pipe.Del(ctx, signalKey(id))
 	pipe.ZRem(ctx, keyByCreatedAt, id)
 	pipe.ZRem(ctx, keyByPriority, id)
-	_, err := pipe.Exec(ctx)
-	return err
+	for _, clearance := range scopedClearances {
+		pipe.ZRem(ctx, indexKey(keyByCreatedAt, clearance), id)
+		pipe.ZRem(ctx, indexKey(keyByPriority, clearance), id)
+	}
 }
 
-// ListByCreatedAt returns signals ordered by newest first.
-func (p SignalProjection) ListByCreatedAt(ctx context.Context, start, stop int64) ([]domain.Signal, error) {
+func (p SignalProjection) evict(ctx context.Context, id string) error {
+	return p.apply(ctx, domain.SignalEvent{Action: domain.ActionDeleted, ID: id}, nil)
+}
+
+// ListByCreatedAt returns the signals visible at clearance, newest first.
+func (p SignalProjection) ListByCreatedAt(ctx context.Context, clearance string, start, stop int64) ([]domain.Signal, error) {
 	ids, err := p.client.ZRangeArgs(ctx, redis.ZRangeArgs{
-		Key:   keyByCreatedAt,
+		Key:   indexKey(keyByCreatedAt, clearance),
 		Start: start,
 		Stop:  stop,
 		Rev:   true,
@@ -80,11 +156,12 @@ func (p SignalProjection) ListByCreatedAt(ctx context.Context, start, stop int64
 	return p.fetchMany(ctx, ids)
 }
 
-// ListByPriority returns signals filtered by priority level.
-func (p SignalProjection) ListByPriority(ctx context.Context, priority string) ([]domain.Signal, error) {
-	score := fmt.Sprintf("%g", priorityScores[priority])
+// ListByPriority returns the signals visible at clearance filtered by
+// priority level.
+func (p SignalProjection) ListByPriority(ctx context.Context, clearance, priority string) ([]domain.Signal, error) {
+	score := fmt.Sprintf("%g", priorityScore(priority))
 	ids, err := p.client.ZRangeArgs(ctx, redis.ZRangeArgs{
-		Key:     keyByPriority,
+		Key:     indexKey(keyByPriority, clearance),
 		Start:   score,
 		Stop:    score,
 		ByScore: true,
@@ -95,16 +172,31 @@ func (p SignalProjection) ListByPriority(ctx context.Context, priority string) (
 	return p.fetchMany(ctx, ids)
 }
 
-// FindByID returns a single signal from the projection.
+// FindByID returns a single signal from the projection, whatever its
+// visibility.
 func (p SignalProjection) FindByID(ctx context.Context, id string) (domain.Signal, error) {
-	data, err := p.client.HGetAll(ctx, signalKey(id)).Result()
+	hashes, err := p.fetchHashes(ctx, []string{id})
 	if err != nil {
 		return domain.Signal{}, err
 	}
-	if len(data) == 0 {
+	if len(hashes[0]) == 0 {
 		return domain.Signal{}, ErrNotFound
 	}
-	return domain.SignalFromMap(data), nil
+	return domain.SignalFromMap(hashes[0]), nil
+}
+
+// FindByIDs returns the signals with the given ids that exist, whatever
+// their visibility, keyed by id. They are fetched in a single round trip.
+func (p SignalProjection) FindByIDs(ctx context.Context, ids []string) (map[string]domain.Signal, error) {
+	hashes, err := p.fetchHashes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	signals := make(map[string]domain.Signal, len(ids))
+	for _, signal := range hydrateSignals(hashes) {
+		signals[signal.ID] = signal
+	}
+	return signals, nil
 }
 
 // Health checks the Redis connection.
@@ -113,25 +205,55 @@ func (p SignalProjection) Health(ctx context.Context) error {
 }
 
 func (p SignalProjection) fetchMany(ctx context.Context, ids []string) ([]domain.Signal, error) {
+	hashes, err := p.fetchHashes(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return hydrateSignals(hashes), nil
+}
+
+// fetchHashes reads the hashes of ids in one pipeline, restricted to
+// p.fields when set. Missing signals read as empty hashes.
+func (p SignalProjection) fetchHashes(ctx context.Context, ids []string) ([]map[string]string, error) {
 	if len(ids) == 0 {
-		return []domain.Signal{}, nil
+		return nil, nil
 	}
 	pipe := p.client.Pipeline()
-	commands := make([]*redis.MapStringStringCmd, len(ids))
+	reads := make([]func() map[string]string, len(ids))
 	for index, id := range ids {
-		commands[index] = pipe.HGetAll(ctx, signalKey(id))
+		reads[index] = p.queueRead(ctx, pipe, signalKey(id))
 	}
-	_, err := pipe.Exec(ctx)
-	if err != nil {
+	if _, err := pipe.Exec(ctx); err != nil {
 		return nil, err
 	}
-	return hydrateSignals(commands), nil
+	hashes := make([]map[string]string, len(ids))
+	for index, read := range reads {
+		hashes[index] = read()
+	}
+	return hashes, nil
 }
 
-func hydrateSignals(commands []*redis.MapStringStringCmd) []domain.Signal {
-	signals := make([]domain.Signal, 0, len(commands))
-	for _, command := range commands {
-		data := command.Val()
+// queueRead queues the read of the hash at key on pipe and returns a
+// function yielding its fields once the pipeline has run.
+func (p SignalProjection) queueRead(ctx context.Context, pipe redis.Pipeliner, key string) func() map[string]string {
+	if p.fields == nil {
+		return pipe.HGetAll(ctx, key).Val
+	}
+	command := pipe.HMGet(ctx, key, p.fields...)
+	return func() map[string]string {
+		data := make(map[string]string, len(p.fields))
+		for index, value := range command.Val() {
+			if value, ok := value.(string); ok {
+				data[p.fields[index]] = value
+			}
+		}
+		return data
+	}
+}
+
+func hydrateSignals(hashes []map[string]string) []domain.Signal {
+	signals := make([]domain.Signal, 0, len(hashes))
+	for _, data := range hashes {
 		if len(data) == 0 {
 			continue
 		}
@@ -140,6 +262,16 @@ func hydrateSignals(commands []*redis.MapStringStringCmd) []domain.Signal {
 	return signals
 }
 
+// indexKey returns the index of base holding the signals visible at
+// clearance. Unknown clearances get the public index.
+func indexKey(base, clearance string) string {
+	clearance = domain.Clearance(clearance)
+	if clearance == domain.VisibilityAdmin {
+		return base
+	}
+	return base + ":" + clearance
+}
+
 func signalKey(id string) string {
 	return "signal:" + id
 }
@@ -89,6 +89,49 @@ func TestApply_Updated(t *testing.T) {
 	}
 }
 
+func TestApply_StoresTraceID(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	event := sampleEvent(domain.ActionCreated, "signal-1")
+	event.TraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+	err := proj.Apply(ctx, event)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signal, err := proj.FindByID(ctx, "signal-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signal.TraceID != event.TraceID {
+		t.Errorf("expected trace id %q, got %q", event.TraceID, signal.TraceID)
+	}
+}
+
+func TestApply_UntracedUpdateClearsTraceID(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	traced := sampleEvent(domain.ActionCreated, "signal-1")
+	traced.TraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	if err := proj.Apply(ctx, traced); err != nil {
+		t.Fatalf("failed to apply traced event: %v", err)
+	}
+
+	err := proj.Apply(ctx, sampleEvent(domain.ActionUpdated, "signal-1"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signal, err := proj.FindByID(ctx, "signal-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signal.TraceID != "" {
+		t.Errorf("expected the trace id of the latest event, got %q", signal.TraceID)
+	}
+}
+
 func TestApply_Deleted(t *testing.T) {
 	proj, _ := setupProjection(t)
 	ctx := context.Background()
@@ -141,7 +184,7 @@ func TestApply_Idempotent(t *testing.T) {
 		t.Fatalf("failed to apply duplicate event: %v", err)
 	}
 
-	signals, err := proj.ListByCreatedAt(ctx, 0, 49)
+	signals, err := proj.ListByCreatedAt(ctx, domain.VisibilityAdmin, 0, 49)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -165,7 +208,7 @@ func TestListByCreatedAt_Empty(t *testing.T) {
 	proj, _ := setupProjection(t)
 	ctx := context.Background()
 
-	signals, err := proj.ListByCreatedAt(ctx, 0, 49)
+	signals, err := proj.ListByCreatedAt(ctx, domain.VisibilityAdmin, 0, 49)
 
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
@@ -192,7 +235,7 @@ func TestListByCreatedAt_Order(t *testing.T) {
 		t.Fatalf("failed to apply newer event: %v", err)
 	}
 
-	signals, err := proj.ListByCreatedAt(ctx, 0, 49)
+	signals, err := proj.ListByCreatedAt(ctx, domain.VisibilityAdmin, 0, 49)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -225,7 +268,7 @@ func TestListByPriority_Filter(t *testing.T) {
 		t.Fatalf("failed to apply low event: %v", err)
 	}
 
-	signals, err := proj.ListByPriority(ctx, "High")
+	signals, err := proj.ListByPriority(ctx, domain.VisibilityAdmin, "High")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -237,6 +280,29 @@ func TestListByPriority_Filter(t *testing.T) {
 	}
 }
 
+func TestListByPriority_EveryDomainPriority(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	for _, priority := range domain.Priorities() {
+		event := sampleEvent(domain.ActionCreated, priority)
+		event.Priority = priority
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply %s event: %v", priority, err)
+		}
+	}
+
+	for _, priority := range domain.Priorities() {
+		signals, err := proj.ListByPriority(ctx, domain.VisibilityAdmin, priority)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(signals) != 1 || signals[0].ID != priority {
+			t.Errorf("expected only signal %q listed for %s, got %v", priority, priority, signals)
+		}
+	}
+}
+
 func TestListByPriority_NoMatch(t *testing.T) {
 	proj, _ := setupProjection(t)
 	ctx := context.Background()
@@ -247,7 +313,7 @@ func TestListByPriority_NoMatch(t *testing.T) {
 		t.Fatalf("failed to apply low event: %v", err)
 	}
 
-	signals, err := proj.ListByPriority(ctx, "High")
+	signals, err := proj.ListByPriority(ctx, domain.VisibilityAdmin, "High")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -267,7 +333,7 @@ func TestListByCreatedAt_RemovedAfterDelete(t *testing.T) {
 		t.Fatalf("failed to apply delete event: %v", err)
 	}
 
-	signals, err := proj.ListByCreatedAt(ctx, 0, 49)
+	signals, err := proj.ListByCreatedAt(ctx, domain.VisibilityAdmin, 0, 49)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -299,3 +365,70 @@ func TestHealth_Unhealthy(t *testing.T) {
 		t.Fatal("expected error for closed connection, got nil")
 	}
 }
+
+func TestFindByIDs(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	for _, id := range []string{"signal-1", "signal-2"} {
+		if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, id)); err != nil {
+			t.Fatalf("failed to apply event: %v", err)
+		}
+	}
+
+	signals, err := proj.FindByIDs(ctx, []string{"signal-2", "missing", "signal-1"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(signals) != 2 {
+		t.Fatalf("expected 2 signals, got %d", len(signals))
+	}
+	if signals["signal-2"].Title != "Server Alert" {
+		t.Errorf("expected signal-2 to be hydrated, got %+v", signals["signal-2"])
+	}
+	if _, found := signals["missing"]; found {
+		t.Errorf("expected no entry for a missing signal")
+	}
+}
+
+func TestOnly_FetchesRequestedFields(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-1")); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	signal, err := proj.Only("title").FindByID(ctx, "signal-1")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signal.Title != "Server Alert" || signal.ID != "signal-1" || signal.UpdatedAt == "" {
+		t.Errorf("expected title, id and updated_at, got %+v", signal)
+	}
+	if signal.Content != "" || signal.Priority != "" || signal.Author != "" {
+		t.Errorf("expected unrequested fields to be empty, got %+v", signal)
+	}
+}
+
+func TestOnly_MissingSignal(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-1")); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+	sparse := proj.Only("priority")
+
+	_, err := sparse.FindByID(ctx, "missing")
+	signals, listErr := sparse.ListByCreatedAt(ctx, domain.VisibilityAdmin, 0, 10)
+
+	if err != projection.ErrNotFound {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if listErr != nil {
+		t.Fatalf("unexpected error: %v", listErr)
+	}
+	if len(signals) != 1 || signals[0].Priority != "High" || signals[0].Title != "" {
+		t.Errorf("expected one signal with only its priority, got %+v", signals)
+	}
+}
@@ -0,0 +1,149 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	entryHash   = "hash"
+	entryZSet   = "zset"
+	entryString = "string"
+
+	scanCount = 500
+)
+
+// projectionPatterns match every key owned by the projection.
+var projectionPatterns = []string{"signal:*", "signals:*"}
+
+// ErrNotEmpty is returned when restoring into a keyspace that already holds
+// projection data.
+var ErrNotEmpty = errors.New("projection keyspace is not empty")
+
+// Entry is a single projection key in a portable form.
+type Entry struct {
+	Key     string            `json:"key"`
+	Type    string            `json:"type"`
+	Value   string            `json:"value,omitempty"`
+	Hash    map[string]string `json:"hash,omitempty"`
+	Members []Member          `json:"members,omitempty"`
+}
+
+// Member is a scored member of a sorted set entry.
+type Member struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// Export walks every projection key and passes it to emit. Locks are
+// skipped, and so are consumer positions: read during the walk, they could
+// be ahead of the keys already emitted, and must come from Positions read
+// beforehand instead. The walk is not a point-in-time view: keys written while it runs
+// may or may not be included, so callers should record consumer positions
+// before exporting and let replay reconcile the difference.
+func (p SignalProjection) Export(ctx context.Context, emit func(Entry) error) error {
+	for _, pattern := range projectionPatterns {
+		iterator := p.client.Scan(ctx, 0, pattern, scanCount).Iterator()
+		for iterator.Next(ctx) {
+			key := iterator.Val()
+			if strings.HasPrefix(key, lockKey("")) || strings.HasPrefix(key, positionPrefix) {
+				continue
+			}
+			entry, found, err := p.exportKey(ctx, key)
+			if err != nil {
+				return err
+			}
+			if !found {
+				continue
+			}
+			if err := emit(entry); err != nil {
+				return err
+			}
+		}
+		if err := iterator.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p SignalProjection) exportKey(ctx context.Context, key string) (Entry, bool, error) {
+	keyType, err := p.client.Type(ctx, key).Result()
+	if err != nil {
+		return Entry{}, false, err
+	}
+	switch keyType {
+	case entryHash:
+		fields, err := p.client.HGetAll(ctx, key).Result()
+		return Entry{Key: key, Type: entryHash, Hash: fields}, len(fields) > 0, err
+	case entryZSet:
+		scored, err := p.client.ZRangeWithScores(ctx, key, 0, -1).Result()
+		members := make([]Member, len(scored))
+		for index, z := range scored {
+			members[index] = Member{Member: fmt.Sprint(z.Member), Score: z.Score}
+		}
+		return Entry{Key: key, Type: entryZSet, Members: members}, len(members) > 0, err
+	case entryString:
+		value, err := p.client.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			return Entry{}, false, nil
+		}
+		return Entry{Key: key, Type: entryString, Value: value}, err == nil, err
+	case "none":
+		return Entry{}, false, nil
+	default:
+		return Entry{}, false, fmt.Errorf("unexpected %s key %q in projection", keyType, key)
+	}
+}
+
+// IsEmpty reports whether the keyspace holds no projection keys.
+func (p SignalProjection) IsEmpty(ctx context.Context) (bool, error) {
+	for _, pattern := range projectionPatterns {
+		iterator := p.client.Scan(ctx, 0, pattern, scanCount).Iterator()
+		for iterator.Next(ctx) {
+			if !strings.HasPrefix(iterator.Val(), lockKey("")) {
+				return false, nil
+			}
+		}
+		if err := iterator.Err(); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// Restore writes exported entries back into the keyspace in a single
+// pipeline. Callers restore in batches and check IsEmpty beforehand.
+// Position entries, which older exports included, are skipped; positions
+// are restored with RestorePositions.
+func (p SignalProjection) Restore(ctx context.Context, entries []Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	pipe := p.client.Pipeline()
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Key, positionPrefix) {
+			continue
+		}
+		switch entry.Type {
+		case entryString:
+			pipe.Set(ctx, entry.Key, entry.Value, 0)
+		case entryHash:
+			pipe.HSet(ctx, entry.Key, entry.Hash)
+		case entryZSet:
+			members := make([]redis.Z, len(entry.Members))
+			for index, member := range entry.Members {
+				members[index] = redis.Z{Score: member.Score, Member: member.Member}
+			}
+			pipe.ZAdd(ctx, entry.Key, members...)
+		default:
+			return fmt.Errorf("unsupported entry type %q for key %q", entry.Type, entry.Key)
+		}
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,296 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyStatsDays   = "signals:stats:days"
+	fieldTotal     = "total"
+	prefixPriority = "priority:"
+	prefixAuthor   = "author:"
+	dayLayout      = "2006-01-02"
+
+	// undatedDay buckets signals whose created_at cannot be parsed, so they
+	// still count towards unbounded totals.
+	undatedDay   = "undated"
+	undatedScore = -1
+)
+
+// statsBucket identifies the counters a single signal contributes to.
+type statsBucket struct {
+	day        string
+	priority   string
+	author     string
+	visibility string
+}
+
+func bucketFromFields(fields map[string]string) statsBucket {
+	return statsBucket{
+		day:        dayOf(fields["created_at"]),
+		priority:   fields["priority"],
+		author:     fields["author"],
+		visibility: fields["visibility"],
+	}
+}
+
+// add queues counter updates for the bucket, in the counters of every
+// clearance the signal is counted at. A negative delta removes a signal that
+// was previously counted.
+func (b statsBucket) add(ctx context.Context, pipe redis.Pipeliner, delta int64) {
+	if delta > 0 {
+		pipe.ZAdd(ctx, keyStatsDays, redis.Z{Score: dayScore(b.day), Member: b.day})
+	}
+	for _, clearance := range domain.Visibilities() {
+		if b.countedAt(clearance) {
+			b.addAt(ctx, pipe, clearance, delta)
+		}
+	}
+}
+
+// countedAt reports whether the bucket's signal is counted at clearance.
+// Signals stored before visibility existed have none and are only counted
+// by the admin counters until MigrateVisibility marks them public.
+func (b statsBucket) countedAt(clearance string) bool {
+	if b.visibility == "" {
+		return clearance == domain.VisibilityAdmin
+	}
+	return domain.Visible(b.visibility, clearance)
+}
+
+func (b statsBucket) addAt(ctx context.Context, pipe redis.Pipeliner, clearance string, delta int64) {
+	key := statsKey(clearance, b.day)
+	pipe.HIncrBy(ctx, key, fieldTotal, delta)
+	pipe.HIncrBy(ctx, key, prefixPriority+b.priority, delta)
+	pipe.HIncrBy(ctx, key, prefixAuthor+b.author, delta)
+}
+
+// storedBucket reads the bucket a signal is currently counted in.
+// Returns false when the signal is not in the projection.
+func storedBucket(ctx context.Context, tx *redis.Tx, id string) (statsBucket, bool, error) {
+	values, err := tx.HMGet(ctx, signalKey(id), bucketFields...).Result()
+	if err != nil {
+		return statsBucket{}, false, err
+	}
+	bucket, exists := bucketFromValues(values)
+	return bucket, exists, nil
+}
+
+// bucketFields are the hash fields bucketFromValues reads.
+var bucketFields = []string{"id", "priority", "author", "created_at", "visibility"}
+
+// bucketFromValues builds a bucket from the values of bucketFields. Returns
+// false when the hash does not exist.
+func bucketFromValues(values []interface{}) (statsBucket, bool) {
+	if values[0] == nil {
+		return statsBucket{}, false
+	}
+	return bucketFromFields(map[string]string{
+		"priority":   stringValue(values[1]),
+		"author":     stringValue(values[2]),
+		"created_at": stringValue(values[3]),
+		"visibility": stringValue(values[4]),
+	}), true
+}
+
+// Stats returns signal counts per priority, author and day for the signals
+// visible at clearance created within [from, to]. Zero bounds are treated
+// as unbounded; signals with an unparseable created_at only count when both
+// are.
+func (p SignalProjection) Stats(ctx context.Context, clearance string, from, to time.Time) (domain.Stats, error) {
+	days, err := p.client.ZRangeArgs(ctx, redis.ZRangeArgs{
+		Key:     keyStatsDays,
+		Start:   lowerBound(from, to),
+		Stop:    upperBound(to),
+		ByScore: true,
+	}).Result()
+	if err != nil {
+		return domain.Stats{}, err
+	}
+
+	pipe := p.client.Pipeline()
+	commands := make([]*redis.MapStringStringCmd, len(days))
+	for index, day := range days {
+		commands[index] = pipe.HGetAll(ctx, statsKey(clearance, day))
+	}
+	if len(days) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return domain.Stats{}, err
+		}
+	}
+	return aggregateStats(days, commands), nil
+}
+
+func aggregateStats(days []string, commands []*redis.MapStringStringCmd) domain.Stats {
+	stats := domain.Stats{
+		ByPriority: map[string]int64{},
+		ByAuthor:   map[string]int64{},
+		ByDay:      []domain.DayCount{},
+	}
+	for index, command := range commands {
+		for field, raw := range command.Val() {
+			count, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || count <= 0 {
+				continue
+			}
+			switch {
+			case field == fieldTotal:
+				stats.Total += count
+				stats.ByDay = append(stats.ByDay, domain.DayCount{Day: days[index], Count: count})
+			case strings.HasPrefix(field, prefixPriority):
+				stats.ByPriority[strings.TrimPrefix(field, prefixPriority)] += count
+			case strings.HasPrefix(field, prefixAuthor):
+				stats.ByAuthor[strings.TrimPrefix(field, prefixAuthor)] += count
+			}
+		}
+	}
+	return stats
+}
+
+// statsKey returns the counters of day at clearance. Admin counters count
+// every signal and keep the unscoped key.
+func statsKey(clearance, day string) string {
+	clearance = domain.Clearance(clearance)
+	if clearance == domain.VisibilityAdmin {
+		return "signals:stats:" + day
+	}
+	return "signals:stats:" + clearance + ":" + day
+}
+
+// keyStatsRebuilt marks a keyspace whose stats counters were rebuilt from
+// the signal hashes, so RebuildStats can return straight away.
+const keyStatsRebuilt = "signals:migrated:stats"
+
+// RebuildStats recounts the stats counters of every clearance from the
+// signal hashes, replacing the stored ones, for keyspaces holding signals
+// projected before the counters existed. It runs once per keyspace, as a
+// single transaction watching every counter: any write applied meanwhile
+// moves a counter and aborts it, and it is retried. Returns how many
+// signals it counted.
+func (p SignalProjection) RebuildStats(ctx context.Context) (int, error) {
+	done, err := p.client.Exists(ctx, keyStatsRebuilt).Result()
+	if err != nil || done > 0 {
+		return 0, err
+	}
+	for range migrateAttempts {
+		var counted int
+		counted, err = p.rebuildStats(ctx)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return counted, err
+		}
+	}
+	return 0, err
+}
+
+func (p SignalProjection) rebuildStats(ctx context.Context) (int, error) {
+	days, err := p.client.ZRange(ctx, keyStatsDays, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+	stored := []string{keyStatsDays}
+	for _, day := range days {
+		for _, clearance := range domain.Visibilities() {
+			stored = append(stored, statsKey(clearance, day))
+		}
+	}
+
+	counted := 0
+	err = p.client.Watch(ctx, func(tx *redis.Tx) error {
+		// A day added before the watch started has a counter left unwatched.
+		watched, err := tx.ZRange(ctx, keyStatsDays, 0, -1).Result()
+		if err != nil {
+			return err
+		}
+		if !slices.Equal(watched, days) {
+			return redis.TxFailedErr
+		}
+		buckets, err := p.storedBuckets(ctx, tx)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(ctx, stored...)
+			for _, bucket := range buckets {
+				bucket.add(ctx, pipe, 1)
+			}
+			pipe.Set(ctx, keyStatsRebuilt, time.Now().UTC().Format(time.RFC3339), 0)
+			return nil
+		})
+		counted = len(buckets)
+		return err
+	}, stored...)
+	return counted, err
+}
+
+// storedBuckets reads the bucket of every projected signal.
+func (p SignalProjection) storedBuckets(ctx context.Context, tx *redis.Tx) ([]statsBucket, error) {
+	ids, err := tx.ZRange(ctx, keyByCreatedAt, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	pipe := tx.Pipeline()
+	commands := make([]*redis.SliceCmd, len(ids))
+	for index, id := range ids {
+		commands[index] = pipe.HMGet(ctx, signalKey(id), bucketFields...)
+	}
+	if len(ids) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+	buckets := make([]statsBucket, 0, len(ids))
+	for _, command := range commands {
+		if bucket, exists := bucketFromValues(command.Val()); exists {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets, nil
+}
+
+func dayOf(createdAt string) string {
+	parsed, err := time.Parse(time.RFC3339, createdAt)
+	if err != nil {
+		return undatedDay
+	}
+	return parsed.UTC().Format(dayLayout)
+}
+
+func dayScore(day string) float64 {
+	parsed, err := time.Parse(dayLayout, day)
+	if err != nil {
+		return undatedScore
+	}
+	return float64(parsed.Unix())
+}
+
+// lowerBound excludes the undated bucket from any range with a bound, so a
+// to-only range counts the same signals as one that also sets from.
+func lowerBound(from, to time.Time) string {
+	switch {
+	case !from.IsZero():
+		return strconv.FormatInt(from.Unix(), 10)
+	case to.IsZero():
+		return "-inf"
+	}
+	return "(" + strconv.Itoa(undatedScore)
+}
+
+func upperBound(to time.Time) string {
+	if to.IsZero() {
+		return "+inf"
+	}
+	return strconv.FormatInt(to.Unix(), 10)
+}
+
+func stringValue(value interface{}) string {
+	text, _ := value.(string)
+	return text
+}
@@ -0,0 +1,246 @@
+package projection_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+)
+
+func mustDay(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t.Fatalf("invalid day %q: %v", value, err)
+	}
+	return parsed
+}
+
+func TestStats_CountsCreatedSignals(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	first := sampleEvent(domain.ActionCreated, "signal-1")
+	second := sampleEvent(domain.ActionCreated, "signal-2")
+	second.Priority = "Low"
+	second.Author = "maria"
+
+	for _, event := range []domain.SignalEvent{first, second} {
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply event: %v", err)
+		}
+	}
+
+	stats, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 2 {
+		t.Errorf("expected total 2, got %d", stats.Total)
+	}
+	if stats.ByPriority["High"] != 1 || stats.ByPriority["Low"] != 1 {
+		t.Errorf("unexpected priority counts: %v", stats.ByPriority)
+	}
+	if stats.ByAuthor["otavio"] != 1 || stats.ByAuthor["maria"] != 1 {
+		t.Errorf("unexpected author counts: %v", stats.ByAuthor)
+	}
+	if len(stats.ByDay) != 1 || stats.ByDay[0].Day != "2026-02-23" || stats.ByDay[0].Count != 2 {
+		t.Errorf("unexpected day counts: %v", stats.ByDay)
+	}
+}
+
+func TestStats_UpdateMovesCounters(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-1")); err != nil {
+		t.Fatalf("failed to apply create event: %v", err)
+	}
+	updated := sampleEvent(domain.ActionUpdated, "signal-1")
+	updated.Priority = "Medium"
+	updated.Author = "maria"
+	if err := proj.Apply(ctx, updated); err != nil {
+		t.Fatalf("failed to apply update event: %v", err)
+	}
+
+	stats, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 1 {
+		t.Errorf("expected total 1, got %d", stats.Total)
+	}
+	if _, ok := stats.ByPriority["High"]; ok {
+		t.Errorf("expected old priority to be removed, got %v", stats.ByPriority)
+	}
+	if stats.ByPriority["Medium"] != 1 {
+		t.Errorf("expected 1 Medium signal, got %v", stats.ByPriority)
+	}
+	if _, ok := stats.ByAuthor["otavio"]; ok {
+		t.Errorf("expected old author to be removed, got %v", stats.ByAuthor)
+	}
+	if stats.ByAuthor["maria"] != 1 {
+		t.Errorf("expected 1 signal by maria, got %v", stats.ByAuthor)
+	}
+}
+
+func TestStats_IdempotentReapply(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	event := sampleEvent(domain.ActionCreated, "signal-1")
+
+	for range 3 {
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply event: %v", err)
+		}
+	}
+
+	stats, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 1 {
+		t.Errorf("expected total 1 after reapply, got %d", stats.Total)
+	}
+}
+
+func TestStats_DeleteRemovesCounters(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-1")); err != nil {
+		t.Fatalf("failed to apply create event: %v", err)
+	}
+	deleteEvent := domain.SignalEvent{Action: domain.ActionDeleted, ID: "signal-1"}
+	for range 2 {
+		if err := proj.Apply(ctx, deleteEvent); err != nil {
+			t.Fatalf("failed to apply delete event: %v", err)
+		}
+	}
+
+	stats, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 0 {
+		t.Errorf("expected total 0 after delete, got %d", stats.Total)
+	}
+	if len(stats.ByPriority) != 0 || len(stats.ByAuthor) != 0 || len(stats.ByDay) != 0 {
+		t.Errorf("expected empty breakdowns, got %+v", stats)
+	}
+}
+
+func TestStats_DateRange(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+
+	older := sampleEvent(domain.ActionCreated, "older")
+	older.CreatedAt = "2026-02-20T10:00:00Z"
+	newer := sampleEvent(domain.ActionCreated, "newer")
+	newer.CreatedAt = "2026-02-25T10:00:00Z"
+	undated := sampleEvent(domain.ActionCreated, "undated")
+	undated.CreatedAt = "not-a-date"
+
+	for _, event := range []domain.SignalEvent{older, newer, undated} {
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply event: %v", err)
+		}
+	}
+
+	ranged, err := proj.Stats(ctx, domain.VisibilityAdmin, mustDay(t, "2026-02-21"), mustDay(t, "2026-02-25"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ranged.Total != 1 {
+		t.Errorf("expected 1 signal in range, got %d", ranged.Total)
+	}
+	if len(ranged.ByDay) != 1 || ranged.ByDay[0].Day != "2026-02-25" {
+		t.Errorf("expected only 2026-02-25 in range, got %v", ranged.ByDay)
+	}
+
+	untilOnly, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, mustDay(t, "2026-02-25"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if untilOnly.Total != 2 {
+		t.Errorf("expected 2 dated signals up to 2026-02-25, got %d", untilOnly.Total)
+	}
+
+	all, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if all.Total != 3 {
+		t.Errorf("expected unbounded total 3, got %d", all.Total)
+	}
+}
+
+func TestRebuildStats_CountsSignalsProjectedBeforeCounters(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+	first := sampleEvent(domain.ActionCreated, "signal-1")
+	second := sampleEvent(domain.ActionCreated, "signal-2")
+	second.Priority = "Low"
+	for _, event := range []domain.SignalEvent{first, second} {
+		if err := proj.Apply(ctx, event); err != nil {
+			t.Fatalf("failed to apply event: %v", err)
+		}
+	}
+	// Counters from before the rebuild only saw one of the signals.
+	for _, key := range server.Keys() {
+		if strings.HasPrefix(key, "signals:stats:") {
+			server.Del(key)
+		}
+	}
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-3")); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	counted, err := proj.RebuildStats(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counted != 3 {
+		t.Errorf("expected 3 counted signals, got %d", counted)
+	}
+	deleted := sampleEvent(domain.ActionDeleted, "signal-2")
+	if err := proj.Apply(ctx, deleted); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+	for _, clearance := range domain.Visibilities() {
+		stats, err := proj.Stats(ctx, clearance, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stats.Total != 2 || stats.ByPriority["High"] != 2 || stats.ByPriority["Low"] != 0 {
+			t.Errorf("expected 2 High signals at %s, got %+v", clearance, stats)
+		}
+	}
+}
+
+func TestRebuildStats_RunsOnce(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	if _, err := proj.RebuildStats(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := proj.Apply(ctx, sampleEvent(domain.ActionCreated, "signal-1")); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+
+	counted, err := proj.RebuildStats(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counted != 0 {
+		t.Errorf("expected a finished rebuild to be skipped, got %d counted", counted)
+	}
+}
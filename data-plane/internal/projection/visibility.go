@@ -0,0 +1,93 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+// keyVisibilityMigrated marks a keyspace whose signals all carry a
+// visibility, so MigrateVisibility can return straight away.
+const keyVisibilityMigrated = "signals:migrated:visibility"
+
+// migrateAttempts bounds how often a signal is retried when a concurrent
+// write aborts its migration.
+const migrateAttempts = 3
+
+// MigrateVisibility marks the signals projected before visibility existed
+// as public and adds them to the scoped indexes and stats counters. Each
+// signal is migrated in its own transaction and only while it has no
+// visibility, so the migration can be interrupted, rerun and run by several
+// instances at once. Returns how many signals it migrated.
+func (p SignalProjection) MigrateVisibility(ctx context.Context) (int, error) {
+	done, err := p.client.Exists(ctx, keyVisibilityMigrated).Result()
+	if err != nil || done > 0 {
+		return 0, err
+	}
+	ids, err := p.client.ZRange(ctx, keyByCreatedAt, 0, -1).Result()
+	if err != nil {
+		return 0, err
+	}
+	migrated := 0
+	for _, id := range ids {
+		changed, err := p.migrateVisibility(ctx, id)
+		if err != nil {
+			return migrated, err
+		}
+		if changed {
+			migrated++
+		}
+	}
+	finished := time.Now().UTC().Format(time.RFC3339)
+	return migrated, p.client.Set(ctx, keyVisibilityMigrated, finished, 0).Err()
+}
+
+func (p SignalProjection) migrateVisibility(ctx context.Context, id string) (bool, error) {
+	var err error
+	for range migrateAttempts {
+		var changed bool
+		changed, err = p.migrateSignal(ctx, id)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return changed, err
+		}
+	}
+	return false, err
+}
+
+func (p SignalProjection) migrateSignal(ctx context.Context, id string) (bool, error) {
+	key := signalKey(id)
+	changed := false
+	err := p.client.Watch(ctx, func(tx *redis.Tx) error {
+		values, err := tx.HMGet(ctx, key, "id", "visibility", "priority", "author", "created_at").Result()
+		if err != nil {
+			return err
+		}
+		if values[0] == nil || values[1] != nil {
+			return nil
+		}
+		fields := map[string]string{
+			"priority":   stringValue(values[2]),
+			"author":     stringValue(values[3]),
+			"created_at": stringValue(values[4]),
+			"visibility": domain.VisibilityPublic,
+		}
+		bucket := bucketFromFields(fields)
+		byCreatedAt := redis.Z{Score: parseTimestamp(fields["created_at"]), Member: id}
+		byPriority := redis.Z{Score: priorityScore(fields["priority"]), Member: id}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, "visibility", domain.VisibilityPublic)
+			for _, clearance := range scopedClearances {
+				pipe.ZAdd(ctx, indexKey(keyByCreatedAt, clearance), byCreatedAt)
+				pipe.ZAdd(ctx, indexKey(keyByPriority, clearance), byPriority)
+				bucket.addAt(ctx, pipe, clearance, 1)
+			}
+			return nil
+		})
+		changed = err == nil
+		return err
+	}, key)
+	return changed, err
+}
@@ -0,0 +1,205 @@
+package projection_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+)
+
+func applyWithVisibility(t *testing.T, proj projection.SignalProjection, id, visibility string) {
+	t.Helper()
+	event := sampleEvent(domain.ActionCreated, id)
+	event.Visibility = visibility
+	if err := proj.Apply(context.Background(), event); err != nil {
+		t.Fatalf("failed to apply event: %v", err)
+	}
+}
+
+func listedIDs(t *testing.T, proj projection.SignalProjection, clearance string) []string {
+	t.Helper()
+	signals, err := proj.ListByCreatedAt(context.Background(), clearance, 0, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := make([]string, 0, len(signals))
+	for _, signal := range signals {
+		ids = append(ids, signal.ID)
+	}
+	return ids
+}
+
+func TestList_FiltersByClearance(t *testing.T) {
+	proj, _ := setupProjection(t)
+	applyWithVisibility(t, proj, "public-1", "")
+	applyWithVisibility(t, proj, "internal-1", domain.VisibilityInternal)
+	applyWithVisibility(t, proj, "admin-1", domain.VisibilityAdmin)
+	tests := map[string]struct {
+		clearance string
+		expected  int
+	}{
+		"public":   {clearance: domain.VisibilityPublic, expected: 1},
+		"internal": {clearance: domain.VisibilityInternal, expected: 2},
+		"admin":    {clearance: domain.VisibilityAdmin, expected: 3},
+		"unknown":  {clearance: "root", expected: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ids := listedIDs(t, proj, test.clearance)
+
+			if len(ids) != test.expected {
+				t.Errorf("expected %d signals, got %v", test.expected, ids)
+			}
+		})
+	}
+}
+
+func TestListByPriority_FiltersByClearance(t *testing.T) {
+	proj, _ := setupProjection(t)
+	applyWithVisibility(t, proj, "public-1", domain.VisibilityPublic)
+	applyWithVisibility(t, proj, "admin-1", domain.VisibilityAdmin)
+
+	signals, err := proj.ListByPriority(context.Background(), domain.VisibilityInternal, "High")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(signals) != 1 || signals[0].ID != "public-1" {
+		t.Errorf("expected only %q, got %v", "public-1", signals)
+	}
+}
+
+func TestApply_VisibilityChangeMovesSignal(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	applyWithVisibility(t, proj, "signal-1", domain.VisibilityPublic)
+	update := sampleEvent(domain.ActionUpdated, "signal-1")
+	update.Visibility = domain.VisibilityAdmin
+
+	if err := proj.Apply(ctx, update); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ids := listedIDs(t, proj, domain.VisibilityPublic); len(ids) != 0 {
+		t.Errorf("expected the signal to leave the public index, got %v", ids)
+	}
+	if ids := listedIDs(t, proj, domain.VisibilityAdmin); len(ids) != 1 {
+		t.Errorf("expected the signal in the admin index, got %v", ids)
+	}
+	stats, err := proj.Stats(ctx, domain.VisibilityPublic, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 0 {
+		t.Errorf("expected public total 0, got %d", stats.Total)
+	}
+}
+
+func TestStats_ScopedByClearance(t *testing.T) {
+	proj, _ := setupProjection(t)
+	ctx := context.Background()
+	applyWithVisibility(t, proj, "public-1", domain.VisibilityPublic)
+	applyWithVisibility(t, proj, "internal-1", domain.VisibilityInternal)
+	applyWithVisibility(t, proj, "admin-1", domain.VisibilityAdmin)
+
+	public, err := proj.Stats(ctx, domain.VisibilityPublic, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	internal, err := proj.Stats(ctx, domain.VisibilityInternal, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	admin, err := proj.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if public.Total != 1 || internal.Total != 2 || admin.Total != 3 {
+		t.Errorf("expected totals 1/2/3, got %d/%d/%d", public.Total, internal.Total, admin.Total)
+	}
+}
+
+// seedLegacySignal stores a signal the way projections did before
+// visibility existed: hash, base indexes and unscoped stats only.
+func seedLegacySignal(t *testing.T, server *miniredis.Miniredis, id string) {
+	t.Helper()
+	server.HSet("signal:"+id,
+		"id", id, "title", "Server Alert", "content", "CPU at 95%", "priority", "High",
+		"author", "otavio", "created_at", "2026-02-23T15:00:00-03:00", "updated_at", "2026-02-23T15:05:00-03:00")
+	if _, err := server.ZAdd("signals:by_created_at", 1771869600, id); err != nil {
+		t.Fatalf("failed to seed index: %v", err)
+	}
+	if _, err := server.ZAdd("signals:by_priority", 3, id); err != nil {
+		t.Fatalf("failed to seed index: %v", err)
+	}
+	if _, err := server.ZAdd("signals:stats:days", 1771804800, "2026-02-23"); err != nil {
+		t.Fatalf("failed to seed stats days: %v", err)
+	}
+	server.HIncrBy("signals:stats:2026-02-23", "total", 1)
+	server.HIncrBy("signals:stats:2026-02-23", "priority:High", 1)
+	server.HIncrBy("signals:stats:2026-02-23", "author:otavio", 1)
+}
+
+func TestMigrateVisibility_BackfillsLegacySignals(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+	seedLegacySignal(t, server, "legacy-1")
+	applyWithVisibility(t, proj, "admin-1", domain.VisibilityAdmin)
+
+	migrated, err := proj.MigrateVisibility(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migrated != 1 {
+		t.Errorf("expected 1 migrated signal, got %d", migrated)
+	}
+	if ids := listedIDs(t, proj, domain.VisibilityPublic); len(ids) != 1 || ids[0] != "legacy-1" {
+		t.Errorf("expected the legacy signal to be public, got %v", ids)
+	}
+	signal, err := proj.FindByID(ctx, "legacy-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signal.Visibility != domain.VisibilityPublic {
+		t.Errorf("expected visibility %q, got %q", domain.VisibilityPublic, signal.Visibility)
+	}
+	stats, err := proj.Stats(ctx, domain.VisibilityPublic, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 1 || stats.ByPriority["High"] != 1 {
+		t.Errorf("expected the legacy signal in the public stats, got %+v", stats)
+	}
+}
+
+func TestMigrateVisibility_RunsOnce(t *testing.T) {
+	proj, server := setupProjection(t)
+	ctx := context.Background()
+	seedLegacySignal(t, server, "legacy-1")
+	if _, err := proj.MigrateVisibility(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	seedLegacySignal(t, server, "legacy-2")
+
+	migrated, err := proj.MigrateVisibility(ctx)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if migrated != 0 {
+		t.Errorf("expected a finished migration to be skipped, got %d migrated", migrated)
+	}
+	stats, err := proj.Stats(ctx, domain.VisibilityPublic, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 1 {
+		t.Errorf("expected public total 1, got %d", stats.Total)
+	}
+}
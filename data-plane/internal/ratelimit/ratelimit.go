@@ -0,0 +1,154 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "ratelimit:"
+
+// takeScript refills the bucket in KEYS[1] for the time elapsed since it was
+// last used, at ARGV[1] tokens per second up to ARGV[2], then takes ARGV[3]
+// tokens, zero to only check, if there is at least one. Time comes from the Redis server, so instances with
+// skewed clocks share buckets consistently. Returns whether a token was
+// taken and the tokens left. Buckets expire once they would be full again.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local cost = tonumber(ARGV[3])
+local time = redis.call("TIME")
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1]) or burst
+local updated = tonumber(state[2]) or now
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate / 1000000)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - cost
+	allowed = 1
+end
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", string.format("%.0f", now))
+redis.call("PEXPIRE", KEYS[1], math.max(1, math.ceil((burst - tokens) / rate * 1000)))
+return {allowed, tostring(tokens)}
+`)
+
+// Limit is a token bucket: up to Burst requests at once, refilled at
+// Requests per Period.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// rate returns the refill rate in tokens per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Decision is the outcome of a request against its caller's bucket.
+type Decision struct {
+	Allowed bool
+	// Limit is the bucket's capacity.
+	Limit int
+	// Remaining is how many requests the caller can make right away.
+	Remaining int
+	// RetryAfter is how long a refused caller has to wait for a token.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// Limiter enforces per-caller token buckets stored in Redis, shared by
+// every instance using the same Redis.
+type Limiter struct {
+	client  *redis.Client
+	limit   Limit
+	clients map[string]Limit
+}
+
+// Option configures a Limiter.
+type Option func(*Limiter)
+
+// WithClientLimit gives the caller authenticated as identity its own limit
+// instead of the default one.
+func WithClientLimit(identity string, limit Limit) Option {
+	return func(l *Limiter) {
+		l.clients[identity] = limit
+	}
+}
+
+// New creates a Limiter applying limit to every caller without a limit of
+// its own.
+func New(client *redis.Client, limit Limit, options ...Option) Limiter {
+	limiter := Limiter{client: client, limit: limit, clients: map[string]Limit{}}
+	for _, option := range options {
+		option(&limiter)
+	}
+	return limiter
+}
+
+// Allow takes a token from the bucket of the caller authenticated as
+// identity or, for anonymous callers, of the client address.
+func (l Limiter) Allow(ctx context.Context, identity, address string) (Decision, error) {
+	limit, ok := l.clients[identity]
+	if !ok {
+		limit = l.limit
+	}
+	key := keyPrefix + "address:" + address
+	if identity != "" {
+		key = keyPrefix + "identity:" + identity
+	}
+	return l.take(ctx, key, limit, 1)
+}
+
+// AllowAuthentication reports whether the client address may attempt to
+// authenticate, which it may until its failed attempts empty a bucket of
+// the default limit. It takes no token.
+func (l Limiter) AllowAuthentication(ctx context.Context, address string) (Decision, error) {
+	return l.take(ctx, failuresKey(address), l.limit, 0)
+}
+
+// FailAuthentication takes a token from the failed-attempts bucket of the
+// client address.
+func (l Limiter) FailAuthentication(ctx context.Context, address string) error {
+	_, err := l.take(ctx, failuresKey(address), l.limit, 1)
+	return err
+}
+
+func (l Limiter) take(ctx context.Context, key string, limit Limit, cost int) (Decision, error) {
+	rate := limit.rate()
+	result, err := takeScript.Run(ctx, l.client, []string{key},
+		strconv.FormatFloat(rate, 'g', -1, 64), limit.Burst, cost).Slice()
+	if err != nil {
+		return Decision{}, err
+	}
+	allowed, _ := result[0].(int64)
+	left, _ := result[1].(string)
+	tokens, err := strconv.ParseFloat(left, 64)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	decision := Decision{
+		Allowed:   allowed == 1,
+		Limit:     limit.Burst,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(limit.Burst) - tokens) / rate),
+	}
+	if !decision.Allowed {
+		decision.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	return decision, nil
+}
+
+func failuresKey(address string) string {
+	return keyPrefix + "failures:" + address
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,133 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+var defaultLimit = ratelimit.Limit{Requests: 1, Period: time.Second, Burst: 2}
+
+func setupLimiter(t *testing.T, options ...ratelimit.Option) (ratelimit.Limiter, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	server.SetTime(time.Date(2026, 2, 23, 15, 0, 0, 0, time.UTC))
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Logf("redis close error: %v", err)
+		}
+	})
+	return ratelimit.New(client, defaultLimit, options...), server
+}
+
+func allow(t *testing.T, limiter ratelimit.Limiter, identity, address string) ratelimit.Decision {
+	t.Helper()
+	decision, err := limiter.Allow(context.Background(), identity, address)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return decision
+}
+
+func TestAllow_RefusesBeyondBurst(t *testing.T) {
+	limiter, _ := setupLimiter(t)
+
+	first := allow(t, limiter, "dashboard", "")
+	second := allow(t, limiter, "dashboard", "")
+	third := allow(t, limiter, "dashboard", "")
+
+	if !first.Allowed || first.Remaining != 1 {
+		t.Errorf("expected the first request allowed with 1 remaining, got %+v", first)
+	}
+	if !second.Allowed || second.Remaining != 0 {
+		t.Errorf("expected the second request allowed with 0 remaining, got %+v", second)
+	}
+	if third.Allowed {
+		t.Fatalf("expected the third request refused, got %+v", third)
+	}
+	if third.RetryAfter != time.Second {
+		t.Errorf("expected retry after 1s, got %v", third.RetryAfter)
+	}
+	if third.Limit != 2 || third.Reset != 2*time.Second {
+		t.Errorf("expected limit 2 resetting in 2s, got %+v", third)
+	}
+}
+
+func TestAllow_Refills(t *testing.T) {
+	limiter, server := setupLimiter(t)
+	allow(t, limiter, "dashboard", "")
+	allow(t, limiter, "dashboard", "")
+
+	server.SetTime(time.Date(2026, 2, 23, 15, 0, 1, 500_000_000, time.UTC))
+	decision := allow(t, limiter, "dashboard", "")
+
+	if !decision.Allowed || decision.Remaining != 0 {
+		t.Errorf("expected a refilled token, got %+v", decision)
+	}
+}
+
+func TestAllow_SeparateBuckets(t *testing.T) {
+	limiter, _ := setupLimiter(t)
+	allow(t, limiter, "dashboard", "10.0.0.1")
+	allow(t, limiter, "dashboard", "10.0.0.1")
+
+	other := allow(t, limiter, "reporting", "10.0.0.1")
+	anonymous := allow(t, limiter, "", "10.0.0.1")
+
+	if !other.Allowed || !anonymous.Allowed {
+		t.Errorf("expected other callers to have their own buckets, got %+v and %+v", other, anonymous)
+	}
+}
+
+func TestAllow_ClientLimit(t *testing.T) {
+	limiter, _ := setupLimiter(t, ratelimit.WithClientLimit("batch", ratelimit.Limit{Requests: 10, Period: time.Second, Burst: 5}))
+
+	decision := allow(t, limiter, "batch", "")
+
+	if decision.Limit != 5 || decision.Remaining != 4 {
+		t.Errorf("expected the client's own limit, got %+v", decision)
+	}
+}
+
+func TestAllow_BucketExpires(t *testing.T) {
+	limiter, server := setupLimiter(t)
+	allow(t, limiter, "dashboard", "")
+
+	server.FastForward(time.Second)
+
+	if server.Exists("ratelimit:identity:dashboard") {
+		t.Errorf("expected the bucket to expire once full again")
+	}
+}
+
+func TestAllowAuthentication_OnlyFailuresCount(t *testing.T) {
+	limiter, _ := setupLimiter(t)
+	ctx := t.Context()
+
+	for range 3 {
+		if decision, err := limiter.AllowAuthentication(ctx, "10.0.0.1"); err != nil || !decision.Allowed {
+			t.Fatalf("expected checks alone to take no token, got %+v, %v", decision, err)
+		}
+	}
+	for range 2 {
+		if err := limiter.FailAuthentication(ctx, "10.0.0.1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	decision, err := limiter.AllowAuthentication(ctx, "10.0.0.1")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decision.Allowed || decision.RetryAfter != time.Second {
+		t.Errorf("expected attempts refused for 1s after 2 failures, got %+v", decision)
+	}
+	if other := allow(t, limiter, "", "10.0.0.1"); !other.Allowed || other.Remaining != 1 {
+		t.Errorf("expected failures kept apart from the request bucket, got %+v", other)
+	}
+}
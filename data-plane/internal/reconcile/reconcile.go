@@ -0,0 +1,252 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+)
+
+// FieldDiff is a single field whose projected value differs from the source.
+type FieldDiff struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// Stale is a signal present on both sides with differing fields.
+type Stale struct {
+	Expected domain.Signal `json:"expected"`
+	Fields   []FieldDiff   `json:"fields"`
+}
+
+// Report lists the drift between the source of truth and the projection.
+// Newer holds projected signals written after the dump, which the dump
+// cannot speak for: they are neither drift nor repaired.
+type Report struct {
+	Checked int             `json:"checked"`
+	Missing []domain.Signal `json:"missing"`
+	Extra   []domain.Signal `json:"extra"`
+	Stale   []Stale         `json:"stale"`
+	Newer   []domain.Signal `json:"newer"`
+}
+
+// InSync reports whether no drift was found.
+func (r Report) InSync() bool {
+	return len(r.Missing) == 0 && len(r.Extra) == 0 && len(r.Stale) == 0
+}
+
+// Load decodes a control-plane dump: a JSON array of signals in the same
+// shape the control plane publishes them.
+func Load(reader io.Reader) ([]domain.Signal, error) {
+	var signals []domain.Signal
+	err := json.NewDecoder(reader).Decode(&signals)
+	return signals, err
+}
+
+// Compare diffs the expected signals against the projected ones, field by
+// field. Timestamps are compared as instants, so formatting differences are
+// not reported as drift.
+//
+// The projection keeps moving while the dump ages, so signals it holds in a
+// later version than the dump's, or created after the newest write in the
+// dump, are reported as Newer instead of stale or extra.
+func Compare(expected, actual []domain.Signal) Report {
+	projected := make(map[string]domain.Signal, len(actual))
+	for _, signal := range actual {
+		projected[signal.ID] = signal
+	}
+
+	report := Report{
+		Checked: len(expected),
+		Missing: []domain.Signal{},
+		Extra:   []domain.Signal{},
+		Stale:   []Stale{},
+		Newer:   []domain.Signal{},
+	}
+	seen := make(map[string]bool, len(expected))
+	var dumpedUntil time.Time
+	for _, want := range expected {
+		dumpedUntil = latest(dumpedUntil, want.CreatedAt, want.UpdatedAt)
+		seen[want.ID] = true
+		got, ok := projected[want.ID]
+		if !ok {
+			report.Missing = append(report.Missing, want)
+			continue
+		}
+		fields := diffFields(want, got)
+		switch {
+		case len(fields) == 0:
+		case after(got.UpdatedAt, want.UpdatedAt):
+			report.Newer = append(report.Newer, got)
+		default:
+			report.Stale = append(report.Stale, Stale{Expected: want, Fields: fields})
+		}
+	}
+	for _, got := range actual {
+		if seen[got.ID] {
+			continue
+		}
+		// Only signals the dump should have seen are extra; an unknown
+		// creation time gives no such proof.
+		createdAt, err := time.Parse(time.RFC3339Nano, got.CreatedAt)
+		if err != nil || createdAt.After(dumpedUntil) {
+			report.Newer = append(report.Newer, got)
+			continue
+		}
+		report.Extra = append(report.Extra, got)
+	}
+	sort.Slice(report.Extra, func(i, j int) bool { return report.Extra[i].ID < report.Extra[j].ID })
+	sort.Slice(report.Newer, func(i, j int) bool { return report.Newer[i].ID < report.Newer[j].ID })
+	return report
+}
+
+// RepairOption configures Repair.
+type RepairOption func(*repairer)
+
+type repairer struct {
+	createMissing bool
+	horizon       time.Time
+}
+
+// WithCreateMissing makes Repair create the signals the projection is
+// missing. It is off by default: the dump cannot tell a signal the
+// projection never received from one deleted or expired after the export,
+// and creating the latter would bring it back.
+func WithCreateMissing() RepairOption {
+	return func(r *repairer) {
+		r.createMissing = true
+	}
+}
+
+// WithRetentionHorizon makes Repair leave alone signals created before
+// horizon, which retention evicts: missing ones are not created and stale
+// ones are not rewritten.
+func WithRetentionHorizon(horizon time.Time) RepairOption {
+	return func(r *repairer) {
+		r.horizon = horizon
+	}
+}
+
+// Repair applies the source of truth to the projection: stale signals are
+// rewritten, extra signals are evicted and, with WithCreateMissing, missing
+// signals are created. Newer signals are left alone. Returns how many
+// signals were repaired before any error.
+func Repair(ctx context.Context, proj projection.SignalProjection, report Report, options ...RepairOption) (int, error) {
+	var settings repairer
+	for _, option := range options {
+		option(&settings)
+	}
+
+	events := make([]domain.SignalEvent, 0, len(report.Missing)+len(report.Stale)+len(report.Extra))
+	if settings.createMissing {
+		for _, signal := range report.Missing {
+			if settings.retained(signal) {
+				events = append(events, eventFor(domain.ActionCreated, signal))
+			}
+		}
+	}
+	for _, stale := range report.Stale {
+		if settings.retained(stale.Expected) {
+			events = append(events, eventFor(domain.ActionUpdated, stale.Expected))
+		}
+	}
+	for _, signal := range report.Extra {
+		events = append(events, domain.SignalEvent{Action: domain.ActionDeleted, ID: signal.ID})
+	}
+
+	for index, event := range events {
+		if err := proj.Apply(ctx, event); err != nil {
+			return index, err
+		}
+	}
+	return len(events), nil
+}
+
+// retained reports whether retention keeps signal. Like retention, it keeps
+// signals without a parseable created_at.
+func (r repairer) retained(signal domain.Signal) bool {
+	createdAt, err := time.Parse(time.RFC3339Nano, signal.CreatedAt)
+	return r.horizon.IsZero() || err != nil || !createdAt.Before(r.horizon)
+}
+
+func eventFor(action domain.Action, signal domain.Signal) domain.SignalEvent {
+	return domain.SignalEvent{
+		Action:     action,
+		ID:         signal.ID,
+		Title:      signal.Title,
+		Content:    signal.Content,
+		Priority:   signal.Priority,
+		Author:     signal.Author,
+		CreatedAt:  signal.CreatedAt,
+		UpdatedAt:  signal.UpdatedAt,
+		Visibility: signal.Visibility,
+	}
+}
+
+func diffFields(expected, actual domain.Signal) []FieldDiff {
+	pairs := []struct {
+		field            string
+		expected, actual string
+		equal            func(a, b string) bool
+	}{
+		{"title", expected.Title, actual.Title, sameText},
+		{"content", expected.Content, actual.Content, sameText},
+		{"priority", expected.Priority, actual.Priority, sameText},
+		{"author", expected.Author, actual.Author, sameText},
+		{"created_at", expected.CreatedAt, actual.CreatedAt, sameInstant},
+		{"updated_at", expected.UpdatedAt, actual.UpdatedAt, sameInstant},
+		{"visibility", expected.Visibility, actual.Visibility, sameVisibility},
+	}
+	var diffs []FieldDiff
+	for _, pair := range pairs {
+		if !pair.equal(pair.expected, pair.actual) {
+			diffs = append(diffs, FieldDiff{Field: pair.field, Expected: pair.expected, Actual: pair.actual})
+		}
+	}
+	return diffs
+}
+
+func sameText(a, b string) bool {
+	return a == b
+}
+
+// sameVisibility treats a missing visibility, as in dumps taken before it
+// existed, as public.
+func sameVisibility(a, b string) bool {
+	return domain.EffectiveVisibility(a) == domain.EffectiveVisibility(b)
+}
+
+// after reports whether timestamp a is later than b. Unparseable timestamps
+// are never later.
+func after(a, b string) bool {
+	parsedA, errA := time.Parse(time.RFC3339Nano, a)
+	parsedB, errB := time.Parse(time.RFC3339Nano, b)
+	return errA == nil && errB == nil && parsedA.After(parsedB)
+}
+
+// latest returns the latest of current and the parseable timestamps.
+func latest(current time.Time, timestamps ...string) time.Time {
+	for _, timestamp := range timestamps {
+		if parsed, err := time.Parse(time.RFC3339Nano, timestamp); err == nil && parsed.After(current) {
+			current = parsed
+		}
+	}
+	return current
+}
+
+func sameInstant(a, b string) bool {
+	if a == b {
+		return true
+	}
+	parsedA, errA := time.Parse(time.RFC3339Nano, a)
+	parsedB, errB := time.Parse(time.RFC3339Nano, b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return parsedA.Equal(parsedB)
+}
@@ -0,0 +1,189 @@
+package reconcile_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/reconcile"
+	"github.com/redis/go-redis/v9"
+)
+
+func sampleSignal(id string) domain.Signal {
+	return domain.Signal{
+		ID:        id,
+		Title:     "Signal " + id,
+		Content:   "Content for " + id,
+		Priority:  "High",
+		Author:    "otavio",
+		CreatedAt: "2026-02-23T15:00:00-03:00",
+		UpdatedAt: "2026-02-23T15:05:00-03:00",
+	}
+}
+
+func TestCompare_InSync(t *testing.T) {
+	signals := []domain.Signal{sampleSignal("s1"), sampleSignal("s2")}
+
+	report := reconcile.Compare(signals, signals)
+
+	if !report.InSync() {
+		t.Errorf("expected no drift, got %+v", report)
+	}
+	if report.Checked != 2 {
+		t.Errorf("expected 2 checked signals, got %d", report.Checked)
+	}
+}
+
+func TestCompare_ReportsMissingExtraAndStale(t *testing.T) {
+	stale := sampleSignal("s2")
+	stale.Priority = "Low"
+	expected := []domain.Signal{sampleSignal("s1"), sampleSignal("s2")}
+	actual := []domain.Signal{stale, sampleSignal("s3")}
+
+	report := reconcile.Compare(expected, actual)
+
+	if len(report.Missing) != 1 || report.Missing[0].ID != "s1" {
+		t.Errorf("expected s1 missing, got %v", report.Missing)
+	}
+	if len(report.Extra) != 1 || report.Extra[0].ID != "s3" {
+		t.Errorf("expected s3 extra, got %v", report.Extra)
+	}
+	if len(report.Stale) != 1 {
+		t.Fatalf("expected 1 stale signal, got %d", len(report.Stale))
+	}
+	fields := report.Stale[0].Fields
+	if len(fields) != 1 || fields[0].Field != "priority" || fields[0].Expected != "High" || fields[0].Actual != "Low" {
+		t.Errorf("expected a single priority diff, got %+v", fields)
+	}
+}
+
+func TestCompare_TimestampsComparedAsInstants(t *testing.T) {
+	expected := sampleSignal("s1")
+	actual := sampleSignal("s1")
+	actual.CreatedAt = "2026-02-23T18:00:00Z"
+
+	report := reconcile.Compare([]domain.Signal{expected}, []domain.Signal{actual})
+
+	if !report.InSync() {
+		t.Errorf("expected equal instants in different zones to match, got %+v", report.Stale)
+	}
+}
+
+func TestCompare_LeavesSignalsNewerThanTheDump(t *testing.T) {
+	updated := sampleSignal("s1")
+	updated.Title = "Edited after the export"
+	updated.UpdatedAt = "2026-02-23T16:00:00-03:00"
+	created := sampleSignal("s2")
+	created.CreatedAt = "2026-02-23T16:00:00-03:00"
+	created.UpdatedAt = created.CreatedAt
+	expected := []domain.Signal{sampleSignal("s1")}
+	actual := []domain.Signal{updated, created}
+
+	report := reconcile.Compare(expected, actual)
+
+	if !report.InSync() {
+		t.Errorf("expected no drift, got %+v", report)
+	}
+	if len(report.Newer) != 2 || report.Newer[0].ID != "s1" || report.Newer[1].ID != "s2" {
+		t.Errorf("expected s1 and s2 newer, got %v", report.Newer)
+	}
+}
+
+func TestLoad_DecodesDump(t *testing.T) {
+	dump := `[{"id": "s1", "title": "Alert", "priority": "High", "author": "otavio"}]`
+
+	signals, err := reconcile.Load(strings.NewReader(dump))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(signals) != 1 || signals[0].Title != "Alert" {
+		t.Errorf("unexpected signals: %v", signals)
+	}
+}
+
+// seedProjection returns a projection holding signals.
+func seedProjection(t *testing.T, signals ...domain.Signal) projection.SignalProjection {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	proj := projection.New(client)
+	for _, signal := range signals {
+		event := domain.SignalEvent{Action: domain.ActionCreated, ID: signal.ID, Title: signal.Title, Content: signal.Content,
+			Priority: signal.Priority, Author: signal.Author, CreatedAt: signal.CreatedAt, UpdatedAt: signal.UpdatedAt}
+		if err := proj.Apply(t.Context(), event); err != nil {
+			t.Fatalf("failed to seed %s: %v", signal.ID, err)
+		}
+	}
+	return proj
+}
+
+// repair compares expected against the projection and repairs it.
+func repair(t *testing.T, proj projection.SignalProjection, expected []domain.Signal, options ...reconcile.RepairOption) int {
+	t.Helper()
+	actual, err := proj.ListByCreatedAt(t.Context(), domain.VisibilityAdmin, 0, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repaired, err := reconcile.Repair(t.Context(), proj, reconcile.Compare(expected, actual), options...)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return repaired
+}
+
+func TestRepair_AppliesSourceOfTruth(t *testing.T) {
+	outdated := sampleSignal("s2")
+	outdated.Title = "Outdated"
+	proj := seedProjection(t, outdated, sampleSignal("s3"))
+	expected := []domain.Signal{sampleSignal("s1"), sampleSignal("s2")}
+
+	repaired := repair(t, proj, expected, reconcile.WithCreateMissing())
+
+	if repaired != 3 {
+		t.Errorf("expected 3 repaired signals, got %d", repaired)
+	}
+	after, err := proj.ListByCreatedAt(t.Context(), domain.VisibilityAdmin, 0, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report := reconcile.Compare(expected, after); !report.InSync() {
+		t.Errorf("expected projection in sync after repair, got %+v", report)
+	}
+}
+
+func TestRepair_KeepsSignalsDeletedAfterTheDump(t *testing.T) {
+	proj := seedProjection(t, sampleSignal("s2"))
+	// s1 was deleted after the dump was exported.
+	expected := []domain.Signal{sampleSignal("s1"), sampleSignal("s2")}
+
+	repaired := repair(t, proj, expected)
+
+	if repaired != 0 {
+		t.Errorf("expected no repaired signals, got %d", repaired)
+	}
+	if _, err := proj.FindByID(t.Context(), "s1"); err == nil {
+		t.Error("expected the deleted signal not to be recreated")
+	}
+}
+
+func TestRepair_SkipsSignalsBeyondRetention(t *testing.T) {
+	outdated := sampleSignal("s2")
+	outdated.Title = "Outdated"
+	proj := seedProjection(t, outdated)
+	expected := []domain.Signal{sampleSignal("s1"), sampleSignal("s2")}
+	horizon := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repaired := repair(t, proj, expected, reconcile.WithCreateMissing(), reconcile.WithRetentionHorizon(horizon))
+
+	if repaired != 0 {
+		t.Errorf("expected no repaired signals, got %d", repaired)
+	}
+	if _, err := proj.FindByID(t.Context(), "s1"); err == nil {
+		t.Error("expected the expired signal not to be recreated")
+	}
+}
@@ -0,0 +1,253 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var errUnexpectedEnd = errors.New("unexpected end of definition")
+
+// Message is a Protobuf message: its name, field names by field number and
+// the messages declared inside it, in declaration order.
+type Message struct {
+	Name   string
+	Fields map[int32]string
+	Nested []Message
+}
+
+// ParseProto extracts the top-level messages of a .proto definition in
+// declaration order, with their nested messages. It understands the subset
+// of the language event schemas need: scalar, repeated and map fields and
+// oneofs. Imports, enums and services are skipped; fields of imported
+// types are named like any other.
+func ParseProto(definition string) ([]Message, error) {
+	parser := protoParser{tokens: tokenize(definition)}
+	return parser.file()
+}
+
+type protoParser struct {
+	tokens   []string
+	position int
+}
+
+func (p *protoParser) next() string {
+	if p.position >= len(p.tokens) {
+		return ""
+	}
+	token := p.tokens[p.position]
+	p.position++
+	return token
+}
+
+func (p *protoParser) file() ([]Message, error) {
+	var messages []Message
+	for {
+		switch token := p.next(); token {
+		case "":
+			return messages, nil
+		case ";":
+		case "message":
+			message, err := p.message()
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, message)
+		case "enum", "service", "extend":
+			p.next()
+			if err := p.skipBlock(); err != nil {
+				return nil, err
+			}
+		case "syntax", "edition", "package", "import", "option":
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q at top level", token)
+		}
+	}
+}
+
+func (p *protoParser) message() (Message, error) {
+	name := p.next()
+	if !isIdentifier(name) {
+		return Message{}, fmt.Errorf("invalid message name %q", name)
+	}
+	if p.next() != "{" {
+		return Message{}, fmt.Errorf("message %s: expected \"{\"", name)
+	}
+	message := Message{Name: name, Fields: map[int32]string{}}
+	if err := p.body(&message); err != nil {
+		return Message{}, err
+	}
+	return message, nil
+}
+
+// body reads fields up to the closing brace, descending into oneofs whose
+// fields belong to the enclosing message.
+func (p *protoParser) body(message *Message) error {
+	for {
+		switch token := p.next(); token {
+		case "":
+			return fmt.Errorf("message %s: %w", message.Name, errUnexpectedEnd)
+		case "}":
+			return nil
+		case ";":
+		case "message":
+			nested, err := p.message()
+			if err != nil {
+				return fmt.Errorf("message %s: %w", message.Name, err)
+			}
+			message.Nested = append(message.Nested, nested)
+		case "enum":
+			p.next()
+			if err := p.skipBlock(); err != nil {
+				return fmt.Errorf("message %s: %w", message.Name, err)
+			}
+		case "oneof":
+			p.next()
+			if p.next() != "{" {
+				return fmt.Errorf("message %s: expected \"{\" after oneof", message.Name)
+			}
+			if err := p.body(message); err != nil {
+				return err
+			}
+		case "option", "reserved", "extensions":
+			if err := p.skipStatement(); err != nil {
+				return fmt.Errorf("message %s: %w", message.Name, err)
+			}
+		default:
+			if err := p.field(message, token); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// field reads "[label] type name = number [options];" starting at first.
+func (p *protoParser) field(message *Message, first string) error {
+	fieldType := first
+	if first == "repeated" || first == "optional" || first == "required" {
+		fieldType = p.next()
+	}
+	if fieldType == "map" {
+		for token := p.next(); token != ">"; token = p.next() {
+			if token == "" {
+				return fmt.Errorf("message %s: %w", message.Name, errUnexpectedEnd)
+			}
+		}
+	}
+	name := p.next()
+	if !isIdentifier(name) || p.next() != "=" {
+		return fmt.Errorf("message %s: malformed field near %q", message.Name, name)
+	}
+	number, err := strconv.ParseInt(p.next(), 10, 32)
+	if err != nil || number < 1 {
+		return fmt.Errorf("message %s: invalid number for field %s", message.Name, name)
+	}
+	if existing, ok := message.Fields[int32(number)]; ok {
+		return fmt.Errorf("message %s: fields %s and %s share number %d", message.Name, existing, name, number)
+	}
+	message.Fields[int32(number)] = name
+	if err := p.skipStatement(); err != nil {
+		return fmt.Errorf("message %s: %w", message.Name, err)
+	}
+	return nil
+}
+
+func (p *protoParser) skipBlock() error {
+	if p.next() != "{" {
+		return errors.New(`expected "{"`)
+	}
+	for depth := 1; depth > 0; {
+		switch p.next() {
+		case "":
+			return errUnexpectedEnd
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+	return nil
+}
+
+func (p *protoParser) skipStatement() error {
+	for {
+		switch p.next() {
+		case "":
+			return errUnexpectedEnd
+		case ";":
+			return nil
+		}
+	}
+}
+
+// tokenize splits a definition into words (identifiers, dotted names and
+// numbers), quoted strings and single punctuation characters, dropping
+// whitespace and comments.
+func tokenize(definition string) []string {
+	var tokens []string
+	for index := 0; index < len(definition); {
+		rest := definition[index:]
+		switch {
+		case strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				return tokens
+			}
+			index += end
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return tokens
+			}
+			index += end + 4
+		case rest[0] == '"' || rest[0] == '\'':
+			end := 1
+			for end < len(rest) && rest[end] != rest[0] {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(rest))
+			tokens = append(tokens, rest[:end])
+			index += end
+		case isWordByte(rest[0]):
+			end := 1
+			for end < len(rest) && isWordByte(rest[end]) {
+				end++
+			}
+			tokens = append(tokens, rest[:end])
+			index += end
+		case strings.IndexByte(" \t\r\n\f\v", rest[0]) >= 0:
+			index++
+		default:
+			tokens = append(tokens, rest[:1])
+			index++
+		}
+	}
+	return tokens
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || b == '.' || isLetter(b) || ('0' <= b && b <= '9')
+}
+
+func isLetter(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+func isIdentifier(token string) bool {
+	if token == "" || !(token[0] == '_' || isLetter(token[0])) {
+		return false
+	}
+	for index := 1; index < len(token); index++ {
+		if token[index] == '.' || !isWordByte(token[index]) {
+			return false
+		}
+	}
+	return true
+}
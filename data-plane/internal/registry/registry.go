@@ -0,0 +1,92 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TypeProtobuf is the schema type of Protobuf schemas.
+const TypeProtobuf = "PROTOBUF"
+
+// ErrSchemaNotFound is returned for schema IDs the registry does not hold.
+var ErrSchemaNotFound = errors.New("schema not found")
+
+// Schema is a schema registered under an ID, as served by a
+// Confluent-compatible schema registry.
+type Schema struct {
+	ID         int
+	Type       string
+	Definition string
+}
+
+// Local is a file-backed stand-in for a schema registry: every <id>.proto
+// file in a directory is a Protobuf schema registered under that ID.
+// Schemas are parsed when loaded, so a broken file fails at startup.
+type Local struct {
+	schemas  map[int]Schema
+	messages map[int][]Message
+}
+
+// LoadLocal reads and parses every <id>.proto file in dir. Other files are
+// ignored.
+func LoadLocal(dir string) (Local, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.proto"))
+	if err != nil {
+		return Local{}, err
+	}
+	local := Local{schemas: map[int]Schema{}, messages: map[int][]Message{}}
+	for _, path := range paths {
+		id, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(path), ".proto"))
+		if err != nil {
+			continue
+		}
+		definition, err := os.ReadFile(path)
+		if err != nil {
+			return Local{}, err
+		}
+		messages, err := ParseProto(string(definition))
+		if err != nil {
+			return Local{}, fmt.Errorf("schema %d: %w", id, err)
+		}
+		local.schemas[id] = Schema{ID: id, Type: TypeProtobuf, Definition: string(definition)}
+		local.messages[id] = messages
+	}
+	return local, nil
+}
+
+// SchemaByID returns the schema registered under id.
+func (l Local) SchemaByID(id int) (Schema, error) {
+	schema, ok := l.schemas[id]
+	if !ok {
+		return Schema{}, fmt.Errorf("%w: id %d", ErrSchemaNotFound, id)
+	}
+	return schema, nil
+}
+
+// MessageFields returns the field names by number of the message a
+// Confluent-framed payload references: the schema ID plus the message
+// indexes path, each index picking a message among the top-level messages
+// or those nested in the previous one. An empty path means the first
+// message.
+func (l Local) MessageFields(schemaID int, indexes []int) (map[int32]string, error) {
+	messages, ok := l.messages[schemaID]
+	if !ok {
+		return nil, fmt.Errorf("%w: id %d", ErrSchemaNotFound, schemaID)
+	}
+	if len(indexes) == 0 {
+		indexes = []int{0}
+	}
+	var message Message
+	for depth, index := range indexes {
+		if index < 0 || index >= len(messages) {
+			return nil, fmt.Errorf("schema %d: no message at %v", schemaID, indexes[:depth+1])
+		}
+		message = messages[index]
+		messages = message.Nested
+	}
+	return message.Fields, nil
+}
@@ -0,0 +1,277 @@
+package registry_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/registry"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const signalSchema = `
+syntax = "proto3";
+package nexus.signals.v1;
+
+import "google/protobuf/timestamp.proto";
+
+/* Events published by the control plane. */
+message SignalEvent {
+  string action = 1; // created, updated or deleted
+  string id = 2;
+  reserved 3, 4;
+  string priority = 5 [deprecated = true];
+  map<string, string> labels = 6;
+  oneof origin {
+    string author = 7;
+    string system = 8;
+  }
+  message Audit {
+    string actor = 1;
+  }
+  repeated google.protobuf.Timestamp seen_at = 9;
+}
+
+enum Priority {
+  LOW = 0;
+}
+
+message Heartbeat {
+  string instance = 1;
+}
+`
+
+func writeSchema(t *testing.T, dir, name, definition string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(definition), 0o644); err != nil {
+		t.Fatalf("failed to write schema: %v", err)
+	}
+}
+
+func TestParseProto(t *testing.T) {
+	messages, err := registry.ParseProto(signalSchema)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 2 || messages[0].Name != "SignalEvent" || messages[1].Name != "Heartbeat" {
+		t.Fatalf("expected SignalEvent and Heartbeat, got %v", messages)
+	}
+	expected := map[int32]string{
+		1: "action", 2: "id", 5: "priority", 6: "labels", 7: "author", 8: "system", 9: "seen_at",
+	}
+	fields := messages[0].Fields
+	if len(fields) != len(expected) {
+		t.Errorf("expected %d fields, got %v", len(expected), fields)
+	}
+	for number, name := range expected {
+		if fields[number] != name {
+			t.Errorf("expected field %d to be %q, got %q", number, name, fields[number])
+		}
+	}
+}
+
+func TestParseProto_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unterminated message": `message SignalEvent { string id = 1;`,
+		"missing number":       `message SignalEvent { string id = ; }`,
+		"duplicate number":     `message SignalEvent { string id = 1; string title = 1; }`,
+		"unknown statement":    `signal SignalEvent {}`,
+		"zero number":          `message SignalEvent { string id = 0; }`,
+		"number out of range":  `message SignalEvent { string id = 4294967296; }`,
+		"missing equals":       `message SignalEvent { string id 1; }`,
+		"missing semicolon":    `message SignalEvent { string id = 1 }`,
+		"invalid message name": `message 1Signal { string id = 1; }`,
+		"unterminated map":     `message SignalEvent { map<string, string labels = 1; }`,
+		"oneof without brace":  `message SignalEvent { oneof origin string author = 1; }`,
+		"unterminated nested":  `message SignalEvent { message Audit { string actor = 1; }`,
+		"unterminated enum":    `enum Priority { LOW = 0;`,
+		"unterminated comment": `message SignalEvent { string id = 1; /* }`,
+		"unterminated import":  `import "google/protobuf/timestamp.proto"`,
+	}
+
+	for name, definition := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := registry.ParseProto(definition)
+
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestParseProto_NestedMessages(t *testing.T) {
+	definition := `
+message SignalEvent {
+  string id = 1;
+  message Audit {
+    string actor = 1;
+    message Change {
+      string field = 1;
+      string before = 2;
+    }
+    Change change = 2;
+  }
+  enum Kind { ALERT = 0; }
+  message Origin {
+    string system = 1;
+  }
+  Audit audit = 2;
+}
+`
+
+	messages, err := registry.ParseProto(definition)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signal := messages[0]
+	if len(signal.Fields) != 2 || signal.Fields[2] != "audit" {
+		t.Errorf("expected nested fields to stay out of SignalEvent, got %v", signal.Fields)
+	}
+	if len(signal.Nested) != 2 || signal.Nested[0].Name != "Audit" || signal.Nested[1].Name != "Origin" {
+		t.Fatalf("expected Audit and Origin nested, got %v", signal.Nested)
+	}
+	audit := signal.Nested[0]
+	if audit.Fields[2] != "change" || len(audit.Nested) != 1 || audit.Nested[0].Fields[2] != "before" {
+		t.Errorf("expected Change nested in Audit, got %+v", audit)
+	}
+}
+
+func TestParseProto_Imports(t *testing.T) {
+	definition := `
+syntax = "proto3";
+import "google/protobuf/timestamp.proto";
+import public "nexus/common.proto";
+import weak 'nexus/legacy.proto';
+option go_package = "example.com/nexus;nexus";
+
+message SignalEvent {
+  google.protobuf.Timestamp created_at = 1;
+  .nexus.common.Author author = 2;
+}
+`
+
+	messages, err := registry.ParseProto(definition)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Fields[1] != "created_at" || messages[0].Fields[2] != "author" {
+		t.Errorf("expected fields of imported types, got %v", messages)
+	}
+}
+
+func TestLoadLocal(t *testing.T) {
+	dir := t.TempDir()
+	writeSchema(t, dir, "7.proto", signalSchema)
+	writeSchema(t, dir, "README.proto", "not a registered schema")
+
+	local, err := registry.LoadLocal(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schema, err := local.SchemaByID(7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schema.Type != registry.TypeProtobuf || schema.Definition != signalSchema {
+		t.Errorf("expected the protobuf schema from 7.proto, got %+v", schema)
+	}
+	fields, err := local.MessageFields(7, []int{1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fields[1] != "instance" {
+		t.Errorf("expected the second message's fields, got %v", fields)
+	}
+	nested, err := local.MessageFields(7, []int{0, 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nested[1] != "actor" {
+		t.Errorf("expected the nested Audit message's fields, got %v", nested)
+	}
+}
+
+// TestLoadLocal_SignalSchemaRoundTrip encodes every event field through the
+// shipped schema and decodes it back, so the .proto and the decoder agree.
+func TestLoadLocal_SignalSchemaRoundTrip(t *testing.T) {
+	local, err := registry.LoadLocal("../../schemas")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names, err := local.MessageFields(1, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := domain.SignalEvent{
+		Action:     domain.ActionCreated,
+		ID:         "abc-123",
+		Title:      "Server Alert",
+		Content:    "CPU at 95%",
+		Priority:   "High",
+		Author:     "otavio",
+		CreatedAt:  "2026-02-23T15:00:00Z",
+		UpdatedAt:  "2026-02-23T15:05:00Z",
+		Visibility: domain.VisibilityInternal,
+	}
+	values := map[string]string{
+		"action": string(expected.Action), "id": expected.ID, "title": expected.Title,
+		"content": expected.Content, "priority": expected.Priority, "author": expected.Author,
+		"created_at": expected.CreatedAt, "updated_at": expected.UpdatedAt, "visibility": expected.Visibility,
+	}
+	payload := binary.BigEndian.AppendUint32([]byte{0}, 1)
+	payload = append(payload, 0)
+	for number, name := range names {
+		payload = protowire.AppendTag(payload, protowire.Number(number), protowire.BytesType)
+		payload = protowire.AppendString(payload, values[name])
+	}
+
+	event, err := domain.NewDecoder(local).Decode(payload, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event != expected {
+		t.Errorf("expected %+v, got %+v", expected, event)
+	}
+}
+
+func TestLoadLocal_InvalidSchema(t *testing.T) {
+	dir := t.TempDir()
+	writeSchema(t, dir, "1.proto", `message SignalEvent {`)
+
+	_, err := registry.LoadLocal(dir)
+
+	if err == nil {
+		t.Fatal("expected error for a broken schema, got nil")
+	}
+}
+
+func TestMessageFields_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeSchema(t, dir, "1.proto", signalSchema)
+	local, err := registry.LoadLocal(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := local.MessageFields(2, nil); !errors.Is(err, registry.ErrSchemaNotFound) {
+		t.Errorf("expected ErrSchemaNotFound, got %v", err)
+	}
+	if _, err := local.MessageFields(1, []int{5}); err == nil {
+		t.Error("expected error for an out of range message index, got nil")
+	}
+	if _, err := local.MessageFields(1, []int{0, 1}); err == nil {
+		t.Error("expected error for an out of range nested message index, got nil")
+	}
+	if _, err := local.MessageFields(1, []int{1, 0}); err == nil {
+		t.Error("expected error for a message without nested messages, got nil")
+	}
+}
@@ -0,0 +1,153 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+)
+
+const (
+	lockName = "retention"
+	// lockTTL frees the lock soon after its holder dies. Live holders keep
+	// refreshing it, however long their sweep runs.
+	lockTTL = 30 * time.Second
+)
+
+// ErrInvalidInterval is returned by Run when the sweep interval is not
+// positive.
+var ErrInvalidInterval = errors.New("retention interval must be positive")
+
+// Policy bounds how much of the signal history the projection keeps.
+// Zero values disable the corresponding rule.
+type Policy struct {
+	MaxAge         time.Duration
+	MaxPerPriority int
+}
+
+// Enabled reports whether the policy has any rule to enforce.
+func (p Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.MaxPerPriority > 0
+}
+
+// Result summarizes a single sweep.
+type Result struct {
+	Skipped bool
+	Expired int
+	Trimmed int
+}
+
+// Sweeper periodically enforces a retention Policy on the projection.
+// Instances coordinate through a Redis lock so only one sweeps at a time.
+type Sweeper struct {
+	projection projection.SignalProjection
+	policy     Policy
+	interval   time.Duration
+}
+
+// New creates a Sweeper that runs every interval.
+func New(proj projection.SignalProjection, policy Policy, interval time.Duration) Sweeper {
+	return Sweeper{projection: proj, policy: policy, interval: interval}
+}
+
+// Run sweeps on every tick. Blocks until the context is cancelled.
+func (s Sweeper) Run(ctx context.Context) error {
+	if s.interval <= 0 {
+		return ErrInvalidInterval
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case now := <-ticker.C:
+			s.logSweep(s.Sweep(ctx, now))
+		}
+	}
+}
+
+// Sweep enforces the policy once, as of now. It is skipped when another
+// instance holds the lock.
+func (s Sweeper) Sweep(ctx context.Context, now time.Time) (Result, error) {
+	lock, acquired, err := s.projection.TryLock(ctx, lockName, lockTTL)
+	if err != nil {
+		return Result{}, err
+	}
+	if !acquired {
+		return Result{Skipped: true}, nil
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	refreshed := make(chan struct{})
+	go func() {
+		defer close(refreshed)
+		keepLock(ctx, lock, cancel)
+	}()
+	defer func() {
+		cancel(nil)
+		<-refreshed
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			slog.ErrorContext(ctx, "retention lock release failed", "error", err)
+		}
+	}()
+
+	var result Result
+	if s.policy.MaxAge > 0 {
+		result.Expired, err = s.projection.ExpireBefore(ctx, now.Add(-s.policy.MaxAge))
+		if err != nil {
+			return result, lockLost(ctx, err)
+		}
+	}
+	if s.policy.MaxPerPriority > 0 {
+		for _, priority := range domain.Priorities() {
+			trimmed, err := s.projection.TrimPriority(ctx, priority, s.policy.MaxPerPriority)
+			result.Trimmed += trimmed
+			if err != nil {
+				return result, lockLost(ctx, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// keepLock refreshes lock until ctx is done, cancelling ctx if the lock is
+// lost so the sweep stops before another instance starts one.
+func keepLock(ctx context.Context, lock projection.Lock, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(lockTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := lock.Refresh(ctx, lockTTL); errors.Is(err, projection.ErrLockNotHeld) {
+				cancel(err)
+				return
+			} else if err != nil {
+				slog.WarnContext(ctx, "retention lock refresh failed", "error", err)
+			}
+		}
+	}
+}
+
+// lockLost reports a sweep stopped because its lock was lost as such.
+func lockLost(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); errors.Is(cause, projection.ErrLockNotHeld) {
+		return cause
+	}
+	return err
+}
+
+func (s Sweeper) logSweep(result Result, err error) {
+	if err != nil {
+		slog.Error("retention sweep failed",
+			"expired", result.Expired, "trimmed", result.Trimmed, "error", err)
+		return
+	}
+	if result.Expired > 0 || result.Trimmed > 0 {
+		slog.Info("retention sweep completed", "expired", result.Expired, "trimmed", result.Trimmed)
+	}
+}
@@ -0,0 +1,133 @@
+package retention_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/retention"
+	"github.com/redis/go-redis/v9"
+)
+
+var now = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func setupProjection(t *testing.T) projection.SignalProjection {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Logf("redis close error: %v", err)
+		}
+	})
+	return projection.New(client)
+}
+
+func seedSignal(t *testing.T, proj projection.SignalProjection, id, priority string, age time.Duration) {
+	t.Helper()
+	createdAt := now.Add(-age).Format(time.RFC3339)
+	event := domain.SignalEvent{
+		Action:    domain.ActionCreated,
+		ID:        id,
+		Title:     "Signal " + id,
+		Priority:  priority,
+		Author:    "otavio",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	if err := proj.Apply(t.Context(), event); err != nil {
+		t.Fatalf("failed to seed signal %s: %v", id, err)
+	}
+}
+
+func TestSweep_ExpiresByAge(t *testing.T) {
+	proj := setupProjection(t)
+	seedSignal(t, proj, "old", "High", 48*time.Hour)
+	seedSignal(t, proj, "fresh", "High", time.Hour)
+	sweeper := retention.New(proj, retention.Policy{MaxAge: 24 * time.Hour}, time.Minute)
+
+	result, err := sweeper.Sweep(context.Background(), now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Expired != 1 {
+		t.Errorf("expected 1 expired signal, got %d", result.Expired)
+	}
+	if _, err := proj.FindByID(context.Background(), "fresh"); err != nil {
+		t.Errorf("expected fresh signal to be kept, got %v", err)
+	}
+}
+
+func TestSweep_TrimsPerPriority(t *testing.T) {
+	proj := setupProjection(t)
+	seedSignal(t, proj, "high-1", "High", 3*time.Hour)
+	seedSignal(t, proj, "high-2", "High", 2*time.Hour)
+	seedSignal(t, proj, "low-1", "Low", 3*time.Hour)
+	seedSignal(t, proj, "low-2", "Low", 2*time.Hour)
+	seedSignal(t, proj, "low-3", "Low", time.Hour)
+	sweeper := retention.New(proj, retention.Policy{MaxPerPriority: 1}, time.Minute)
+
+	result, err := sweeper.Sweep(context.Background(), now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Trimmed != 3 {
+		t.Errorf("expected 3 trimmed signals, got %d", result.Trimmed)
+	}
+	signals, err := proj.ListByCreatedAt(context.Background(), domain.VisibilityAdmin, 0, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(signals) != 2 {
+		t.Fatalf("expected 2 remaining signals, got %d", len(signals))
+	}
+	if signals[0].ID != "low-3" || signals[1].ID != "high-2" {
+		t.Errorf("expected newest per priority to remain, got %q and %q", signals[0].ID, signals[1].ID)
+	}
+}
+
+func TestSweep_SkippedWhileLocked(t *testing.T) {
+	proj := setupProjection(t)
+	seedSignal(t, proj, "old", "High", 48*time.Hour)
+	if _, acquired, err := proj.TryLock(context.Background(), "retention", time.Minute); err != nil || !acquired {
+		t.Fatalf("failed to take lock: %v", err)
+	}
+	sweeper := retention.New(proj, retention.Policy{MaxAge: time.Hour}, time.Minute)
+
+	result, err := sweeper.Sweep(context.Background(), now)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Skipped {
+		t.Error("expected sweep to be skipped while another instance holds the lock")
+	}
+	if _, err := proj.FindByID(context.Background(), "old"); err != nil {
+		t.Errorf("expected signal to survive skipped sweep, got %v", err)
+	}
+}
+
+func TestRun_RejectsNonPositiveInterval(t *testing.T) {
+	sweeper := retention.New(setupProjection(t), retention.Policy{MaxAge: time.Hour}, 0)
+
+	err := sweeper.Run(context.Background())
+
+	if !errors.Is(err, retention.ErrInvalidInterval) {
+		t.Errorf("expected ErrInvalidInterval, got %v", err)
+	}
+}
+
+func TestPolicyEnabled(t *testing.T) {
+	if (retention.Policy{}).Enabled() {
+		t.Error("expected zero policy to be disabled")
+	}
+	if !(retention.Policy{MaxPerPriority: 10}).Enabled() {
+		t.Error("expected policy with a per-priority limit to be enabled")
+	}
+}
@@ -0,0 +1,199 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/domain"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/projection"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/snapshot"
+	"github.com/redis/go-redis/v9"
+)
+
+func setupProjection(t *testing.T) projection.SignalProjection {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Logf("redis close error: %v", err)
+		}
+	})
+	return projection.New(client)
+}
+
+func seedSignal(t *testing.T, proj projection.SignalProjection, id, priority, createdAt string) {
+	t.Helper()
+	event := domain.SignalEvent{
+		Action:    domain.ActionCreated,
+		ID:        id,
+		Title:     "Signal " + id,
+		Content:   "Content for " + id,
+		Priority:  priority,
+		Author:    "otavio",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	if err := proj.Apply(t.Context(), event); err != nil {
+		t.Fatalf("failed to seed signal %s: %v", id, err)
+	}
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := setupProjection(t)
+	seedSignal(t, source, "s1", "High", "2026-02-23T15:00:00Z")
+	seedSignal(t, source, "s2", "Low", "2026-02-22T10:00:00Z")
+	position := domain.Position{Topic: "nexus.signals", Partition: 0, Offset: 41}
+	if err := source.Advance(ctx, position); err != nil {
+		t.Fatalf("failed to record position: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	exported, err := snapshot.Export(ctx, source, &buffer)
+	if err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+
+	target := setupProjection(t)
+	header, imported, err := snapshot.Import(ctx, target, &buffer)
+
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	if imported != exported {
+		t.Errorf("expected %d imported entries, got %d", exported, imported)
+	}
+	if len(header.Positions) != 1 || header.Positions[0].Offset != 41 {
+		t.Errorf("expected recorded positions in the header, got %v", header.Positions)
+	}
+	restored, err := target.Positions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(restored) != 1 || restored[0] != position {
+		t.Errorf("expected positions to be restored into the keyspace, got %v", restored)
+	}
+	signals, err := target.ListByCreatedAt(ctx, domain.VisibilityAdmin, 0, -1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(signals) != 2 || signals[0].ID != "s1" {
+		t.Errorf("expected both signals newest first, got %v", signals)
+	}
+	stats, err := target.Stats(ctx, domain.VisibilityAdmin, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Total != 2 || stats.ByPriority["Low"] != 1 {
+		t.Errorf("expected stats counters to round trip, got %+v", stats)
+	}
+}
+
+// advancingWriter moves a position forward once the snapshot header has
+// been written, as an event applied while the keys are being exported would.
+type advancingWriter struct {
+	bytes.Buffer
+	advance func()
+}
+
+func (w *advancingWriter) Write(data []byte) (int, error) {
+	if w.advance != nil {
+		defer func() {
+			w.advance()
+			w.advance = nil
+		}()
+	}
+	return w.Buffer.Write(data)
+}
+
+func TestExportImport_PositionsFromHeader(t *testing.T) {
+	ctx := context.Background()
+	source := setupProjection(t)
+	seedSignal(t, source, "s1", "High", "2026-02-23T15:00:00Z")
+	recorded := domain.Position{Topic: "nexus.signals", Partition: 0, Offset: 41}
+	if err := source.Advance(ctx, recorded); err != nil {
+		t.Fatalf("failed to record position: %v", err)
+	}
+	writer := &advancingWriter{advance: func() {
+		if err := source.Advance(ctx, domain.Position{Topic: "nexus.signals", Partition: 0, Offset: 42}); err != nil {
+			t.Errorf("failed to advance position: %v", err)
+		}
+	}}
+	if _, err := snapshot.Export(ctx, source, writer); err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+
+	target := setupProjection(t)
+	_, _, err := snapshot.Import(ctx, target, &writer.Buffer)
+
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+	restored, err := target.Positions(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(restored) != 1 || restored[0] != recorded {
+		t.Errorf("expected the position read before the keys, %v, got %v", recorded, restored)
+	}
+}
+
+func TestExport_SkipsLocks(t *testing.T) {
+	ctx := context.Background()
+	source := setupProjection(t)
+	seedSignal(t, source, "s1", "High", "2026-02-23T15:00:00Z")
+	if _, _, err := source.TryLock(ctx, "retention", time.Minute); err != nil {
+		t.Fatalf("failed to take lock: %v", err)
+	}
+
+	var buffer bytes.Buffer
+	if _, err := snapshot.Export(ctx, source, &buffer); err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+
+	if strings.Contains(buffer.String(), "signals:lock:") {
+		t.Error("expected locks to be excluded from the snapshot")
+	}
+}
+
+func TestImport_RejectsNonEmptyKeyspace(t *testing.T) {
+	ctx := context.Background()
+	source := setupProjection(t)
+	seedSignal(t, source, "s1", "High", "2026-02-23T15:00:00Z")
+	var buffer bytes.Buffer
+	if _, err := snapshot.Export(ctx, source, &buffer); err != nil {
+		t.Fatalf("unexpected export error: %v", err)
+	}
+
+	_, _, err := snapshot.Import(ctx, source, &buffer)
+
+	if !errors.Is(err, projection.ErrNotEmpty) {
+		t.Errorf("expected ErrNotEmpty, got %v", err)
+	}
+}
+
+func TestImport_RejectsUnknownVersion(t *testing.T) {
+	input := strings.NewReader(`{"format":"nexus-projection-snapshot","version":99}` + "\n")
+
+	_, _, err := snapshot.Import(context.Background(), setupProjection(t), input)
+
+	if !errors.Is(err, snapshot.ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestImport_RejectsForeignFile(t *testing.T) {
+	input := strings.NewReader(`{"id":"s1","title":"not a snapshot"}` + "\n")
+
+	_, _, err := snapshot.Import(context.Background(), setupProjection(t), input)
+
+	if err == nil {
+		t.Fatal("expected error for a file without a snapshot header, got nil")
+	}
+}
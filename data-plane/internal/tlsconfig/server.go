@@ -0,0 +1,151 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Client certificate policies accepted by ServerOptions.ClientAuth.
+const (
+	// ClientAuthNone does not ask for client certificates.
+	ClientAuthNone = "none"
+	// ClientAuthOptional verifies a client certificate when one is presented.
+	ClientAuthOptional = "optional"
+	// ClientAuthRequire refuses clients without a valid certificate.
+	ClientAuthRequire = "require"
+)
+
+// ServerOptions describes how a server verifies its clients.
+type ServerOptions struct {
+	// ClientAuth is one of the ClientAuth constants; empty means none.
+	ClientAuth string
+	// ClientCAFile is a PEM bundle of the authorities trusted to sign client
+	// certificates. Required unless ClientAuth is none.
+	ClientCAFile string
+}
+
+// Server builds a server TLS configuration presenting the certificate
+// certificates currently holds.
+func Server(certificates *CertReloader, options ServerOptions) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: certificates.GetCertificate,
+	}
+	switch options.ClientAuth {
+	case "", ClientAuthNone:
+		return config, nil
+	case ClientAuthOptional:
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequire:
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("unknown client auth %q (want %q, %q or %q)",
+			options.ClientAuth, ClientAuthNone, ClientAuthOptional, ClientAuthRequire)
+	}
+	if options.ClientCAFile == "" {
+		return nil, fmt.Errorf("client auth %q needs a client CA file", options.ClientAuth)
+	}
+	pool, err := LoadCertPool(options.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientCAs = pool
+	return config, nil
+}
+
+// CertReloader serves a certificate loaded from PEM files and reloads it
+// when the files change, so renewed certificates are picked up without a
+// restart.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	modified    time.Time
+}
+
+// NewCertReloader loads the certificate and key pair.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	reloader := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if _, err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// GetCertificate returns the current certificate. It is a
+// tls.Config.GetCertificate callback.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate, nil
+}
+
+// Reload loads the pair again if either file was modified since the last
+// load, and reports whether it did. A pair that fails to load leaves the
+// current certificate in place.
+func (r *CertReloader) Reload() (bool, error) {
+	modified, err := latestModification(r.certFile, r.keyFile)
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	unchanged := r.certificate != nil && modified.Equal(r.modified)
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("loading server certificate: %w", err)
+	}
+	r.mu.Lock()
+	r.certificate = &certificate
+	r.modified = modified
+	r.mu.Unlock()
+	return true, nil
+}
+
+// Run checks the files for changes every interval. Blocks until the context
+// is cancelled.
+func (r *CertReloader) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				slog.ErrorContext(ctx, "certificate reload failed, keeping the current one",
+					"cert_file", r.certFile, "error", err)
+				continue
+			}
+			if reloaded {
+				slog.InfoContext(ctx, "certificate reloaded", "cert_file", r.certFile)
+			}
+		}
+	}
+}
+
+func latestModification(paths ...string) (time.Time, error) {
+	var latest time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("checking server certificate: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,169 @@
+package tlsconfig_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tlsconfig"
+)
+
+// serveTLS serves an endpoint echoing the client certificate's common name
+// over config, and returns its URL.
+func serveTLS(t *testing.T, config *tls.Config) string {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if len(request.TLS.PeerCertificates) > 0 {
+				writer.Header().Set("X-Client", request.TLS.PeerCertificates[0].Subject.CommonName)
+			}
+		}),
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Logf("server close error: %v", err)
+		}
+	})
+	return "https://" + listener.Addr().String()
+}
+
+func get(t *testing.T, url string, config *tls.Config) (*http.Response, error) {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: config}, Timeout: 5 * time.Second}
+	response, err := client.Get(url)
+	if err == nil {
+		t.Cleanup(func() { _ = response.Body.Close() })
+	}
+	return response, err
+}
+
+func TestServer_RequiresClientCertificate(t *testing.T) {
+	ca := newAuthority(t)
+	ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	ca.issue(t, "reporting", x509.ExtKeyUsageClientAuth)
+	certificates, err := tlsconfig.NewCertReloader(ca.path("server.pem"), ca.path("server-key.pem"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	config, err := tlsconfig.Server(certificates, tlsconfig.ServerOptions{
+		ClientAuth:   tlsconfig.ClientAuthRequire,
+		ClientCAFile: ca.path("ca.pem"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	url := serveTLS(t, config)
+	anonymous, err := tlsconfig.Client(tlsconfig.Options{CAFile: ca.path("ca.pem")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authenticated, err := tlsconfig.Client(tlsconfig.Options{
+		CAFile:   ca.path("ca.pem"),
+		CertFile: ca.path("reporting.pem"),
+		KeyFile:  ca.path("reporting-key.pem"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := get(t, url, anonymous); err == nil {
+		t.Error("expected a client without a certificate to be refused")
+	}
+	response, err := get(t, url, authenticated)
+	if err != nil {
+		t.Fatalf("expected mutual TLS to succeed, got %v", err)
+	}
+	if client := response.Header.Get("X-Client"); client != "reporting" {
+		t.Errorf("expected the server to see client %q, got %q", "reporting", client)
+	}
+}
+
+func TestServer_InvalidOptions(t *testing.T) {
+	ca := newAuthority(t)
+	ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	certificates, err := tlsconfig.NewCertReloader(ca.path("server.pem"), ca.path("server-key.pem"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := map[string]tlsconfig.ServerOptions{
+		"unknown client auth": {ClientAuth: "sometimes", ClientCAFile: ca.path("ca.pem")},
+		"missing client CA":   {ClientAuth: tlsconfig.ClientAuthOptional},
+	}
+
+	for name, options := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := tlsconfig.Server(certificates, options)
+
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestCertReloader_ReloadsChangedFiles(t *testing.T) {
+	ca := newAuthority(t)
+	ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	certificates, err := tlsconfig.NewCertReloader(ca.path("server.pem"), ca.path("server-key.pem"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unchanged, err := certificates.Reload()
+	if err != nil || unchanged {
+		t.Fatalf("expected no reload without changes, got %t and %v", unchanged, err)
+	}
+
+	second := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	touch(t, ca.path("server.pem"), time.Now().Add(time.Minute))
+	reloaded, err := certificates.Reload()
+
+	if err != nil || !reloaded {
+		t.Fatalf("expected a reload after the files changed, got %t and %v", reloaded, err)
+	}
+	current, _ := certificates.GetCertificate(nil)
+	if !bytes.Equal(current.Certificate[0], second.Certificate[0]) {
+		t.Error("expected the renewed certificate to be served")
+	}
+}
+
+func TestCertReloader_KeepsCurrentOnBrokenPair(t *testing.T) {
+	ca := newAuthority(t)
+	first := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	certificates, err := tlsconfig.NewCertReloader(ca.path("server.pem"), ca.path("server-key.pem"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := os.WriteFile(ca.path("server-key.pem"), []byte("half written"), 0o600); err != nil {
+		t.Fatalf("failed to corrupt key: %v", err)
+	}
+	touch(t, ca.path("server-key.pem"), time.Now().Add(time.Minute))
+
+	_, err = certificates.Reload()
+
+	if err == nil {
+		t.Error("expected error for a broken pair, got nil")
+	}
+	current, _ := certificates.GetCertificate(nil)
+	if !bytes.Equal(current.Certificate[0], first.Certificate[0]) {
+		t.Error("expected the current certificate to stay in place")
+	}
+}
+
+func touch(t *testing.T, path string, modified time.Time) {
+	t.Helper()
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatalf("failed to touch %s: %v", path, err)
+	}
+}
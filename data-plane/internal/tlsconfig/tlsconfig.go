@@ -0,0 +1,72 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrIncompleteKeyPair is returned when only one of a certificate and its
+// key is given.
+var ErrIncompleteKeyPair = errors.New("a client certificate needs both a certificate and a key file")
+
+// Options describes the client side of a TLS connection. Empty fields keep
+// the system defaults.
+type Options struct {
+	// CAFile is a PEM bundle of the authorities trusted to sign the server
+	// certificate, replacing the system pool.
+	CAFile string
+	// CertFile and KeyFile are the PEM client certificate and key presented
+	// to servers requiring mutual TLS.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the name the server certificate is verified
+	// against, for brokers reached through an address it does not list.
+	ServerName string
+	// InsecureSkipVerify disables server certificate verification. For
+	// local testing only.
+	InsecureSkipVerify bool
+}
+
+// Client builds a client TLS configuration from options, loading the files
+// it names.
+func Client(options Options) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         options.ServerName,
+		InsecureSkipVerify: options.InsecureSkipVerify,
+	}
+	if options.CAFile != "" {
+		pool, err := LoadCertPool(options.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if options.CertFile != "" || options.KeyFile != "" {
+		if options.CertFile == "" || options.KeyFile == "" {
+			return nil, ErrIncompleteKeyPair
+		}
+		certificate, err := tls.LoadX509KeyPair(options.CertFile, options.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// LoadCertPool reads a PEM bundle of certificates into a new pool.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
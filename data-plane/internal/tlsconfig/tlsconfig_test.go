@@ -0,0 +1,245 @@
+package tlsconfig_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tlsconfig"
+	"github.com/redis/go-redis/v9"
+)
+
+// authority is a throwaway CA issuing certificates for a test.
+type authority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	dir         string
+}
+
+func newAuthority(t *testing.T) authority {
+	t.Helper()
+	key := generateKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA: %v", err)
+	}
+	ca := authority{certificate: certificate, key: key, dir: t.TempDir()}
+	writePEM(t, ca.path("ca.pem"), "CERTIFICATE", der)
+	return ca
+}
+
+func (a authority) path(name string) string {
+	return filepath.Join(a.dir, name)
+}
+
+// issue signs a certificate for 127.0.0.1 and writes it and its key as
+// <name>.pem and <name>-key.pem.
+func (a authority) issue(t *testing.T, name string, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+	key := generateKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.certificate, &key.PublicKey, a.key)
+	if err != nil {
+		t.Fatalf("failed to issue certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	writePEM(t, a.path(name+".pem"), "CERTIFICATE", der)
+	writePEM(t, a.path(name+"-key.pem"), "EC PRIVATE KEY", keyDER)
+	certificate, err := tls.LoadX509KeyPair(a.path(name+".pem"), a.path(name+"-key.pem"))
+	if err != nil {
+		t.Fatalf("failed to load issued pair: %v", err)
+	}
+	return certificate
+}
+
+func (a authority) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(a.certificate)
+	return pool
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	content := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+// startRedis runs a TLS miniredis requiring ACL credentials, and client
+// certificates when mutual is set.
+func startRedis(t *testing.T, ca authority, mutual bool) *miniredis.Miniredis {
+	t.Helper()
+	serverConfig := &tls.Config{
+		Certificates: []tls.Certificate{ca.issue(t, "redis", x509.ExtKeyUsageServerAuth)},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if mutual {
+		serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		serverConfig.ClientCAs = ca.pool()
+	}
+	server, err := miniredis.RunTLS(serverConfig)
+	if err != nil {
+		t.Fatalf("failed to start redis: %v", err)
+	}
+	t.Cleanup(server.Close)
+	server.RequireUserAuth("projection", "s3cret")
+	return server
+}
+
+func ping(t *testing.T, addr string, config *tls.Config) error {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{
+		Addr:       addr,
+		Username:   "projection",
+		Password:   "s3cret",
+		TLSConfig:  config,
+		MaxRetries: -1,
+	})
+	t.Cleanup(func() {
+		if err := client.Close(); err != nil {
+			t.Logf("redis close error: %v", err)
+		}
+	})
+	return client.Ping(context.Background()).Err()
+}
+
+func TestClient_TrustsCustomCA(t *testing.T) {
+	ca := newAuthority(t)
+	server := startRedis(t, ca, false)
+
+	config, err := tlsconfig.Client(tlsconfig.Options{CAFile: ca.path("ca.pem")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := ping(t, server.Addr(), config); err != nil {
+		t.Errorf("expected an authenticated TLS connection, got %v", err)
+	}
+}
+
+func TestClient_RejectsUnknownCA(t *testing.T) {
+	server := startRedis(t, newAuthority(t), false)
+	other := newAuthority(t)
+
+	config, err := tlsconfig.Client(tlsconfig.Options{CAFile: other.path("ca.pem")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var unknown x509.UnknownAuthorityError
+	if err := ping(t, server.Addr(), config); !errors.As(err, &unknown) {
+		t.Errorf("expected an unknown authority error, got %v", err)
+	}
+}
+
+func TestClient_PresentsClientCertificate(t *testing.T) {
+	ca := newAuthority(t)
+	server := startRedis(t, ca, true)
+	ca.issue(t, "data-plane", x509.ExtKeyUsageClientAuth)
+
+	withoutCertificate, err := tlsconfig.Client(tlsconfig.Options{CAFile: ca.path("ca.pem")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	withCertificate, err := tlsconfig.Client(tlsconfig.Options{
+		CAFile:   ca.path("ca.pem"),
+		CertFile: ca.path("data-plane.pem"),
+		KeyFile:  ca.path("data-plane-key.pem"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := ping(t, server.Addr(), withoutCertificate); err == nil {
+		t.Error("expected the server to refuse a client without a certificate")
+	}
+	if err := ping(t, server.Addr(), withCertificate); err != nil {
+		t.Errorf("expected mutual TLS to succeed, got %v", err)
+	}
+}
+
+func TestClient_InvalidOptions(t *testing.T) {
+	ca := newAuthority(t)
+	ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	tests := map[string]tlsconfig.Options{
+		"missing CA file":  {CAFile: ca.path("missing.pem")},
+		"CA file not PEM":  {CAFile: ca.path("client-key.pem")},
+		"certificate only": {CertFile: ca.path("client.pem")},
+		"key only":         {KeyFile: ca.path("client-key.pem")},
+		"mismatched pair":  {CertFile: ca.path("client.pem"), KeyFile: writeJunk(t)},
+	}
+
+	for name, options := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := tlsconfig.Client(options)
+
+			if err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadCertPool_NoCertificates(t *testing.T) {
+	_, err := tlsconfig.LoadCertPool(writeJunk(t))
+
+	if err == nil {
+		t.Error("expected error for a file without certificates, got nil")
+	}
+}
+
+func writeJunk(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "junk.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("failed to write junk: %v", err)
+	}
+	return path
+}
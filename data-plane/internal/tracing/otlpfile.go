@@ -0,0 +1,230 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// FileExporter appends spans to a file in the OTLP/JSON encoding, one
+// ExportTraceServiceRequest per line — the layout the OpenTelemetry
+// Collector's otlpjsonfile receiver reads.
+type FileExporter struct {
+	mu      sync.Mutex
+	writer  io.WriteCloser
+	encoder *json.Encoder
+}
+
+// NewFileExporter opens path for appending, creating it if needed.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileExporter{writer: file, encoder: json.NewEncoder(file)}, nil
+}
+
+// ExportSpans writes spans as a single request line.
+func (e *FileExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.encoder.Encode(otlpRequestOf(spans))
+}
+
+// Shutdown closes the file.
+func (e *FileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.writer.Close()
+}
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Events            []otlpEvent     `json:"events,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpEvent struct {
+	Name         string          `json:"name"`
+	TimeUnixNano string          `json:"timeUnixNano"`
+	Attributes   []otlpAttribute `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpValue `json:"values"`
+}
+
+// otlpStatusCodes maps SDK status codes, whose numbering differs from
+// OTLP's (Unset 0, Ok 1, Error 2).
+var otlpStatusCodes = map[codes.Code]int{
+	codes.Unset: 0,
+	codes.Ok:    1,
+	codes.Error: 2,
+}
+
+// otlpRequestOf groups spans by resource and instrumentation scope.
+func otlpRequestOf(spans []sdktrace.ReadOnlySpan) otlpRequest {
+	var request otlpRequest
+	resources := map[string]int{}
+	scopes := map[[2]string]int{}
+	for _, span := range spans {
+		resourceKey := span.Resource().Encoded(attribute.DefaultEncoder())
+		resourceIndex, ok := resources[resourceKey]
+		if !ok {
+			resourceIndex = len(request.ResourceSpans)
+			resources[resourceKey] = resourceIndex
+			request.ResourceSpans = append(request.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{Attributes: otlpAttributes(span.Resource().Attributes())},
+			})
+		}
+		resourceSpans := &request.ResourceSpans[resourceIndex]
+
+		scope := span.InstrumentationScope()
+		scopeKey := [2]string{resourceKey, scope.Name + "@" + scope.Version}
+		scopeIndex, ok := scopes[scopeKey]
+		if !ok {
+			scopeIndex = len(resourceSpans.ScopeSpans)
+			scopes[scopeKey] = scopeIndex
+			resourceSpans.ScopeSpans = append(resourceSpans.ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{Name: scope.Name, Version: scope.Version},
+			})
+		}
+		scopeSpans := &resourceSpans.ScopeSpans[scopeIndex]
+		scopeSpans.Spans = append(scopeSpans.Spans, otlpSpanOf(span))
+	}
+	return request
+}
+
+func otlpSpanOf(span sdktrace.ReadOnlySpan) otlpSpan {
+	converted := otlpSpan{
+		TraceID:           span.SpanContext().TraceID().String(),
+		SpanID:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		Attributes:        otlpAttributes(span.Attributes()),
+		Status: otlpStatus{
+			Code:    otlpStatusCodes[span.Status().Code],
+			Message: span.Status().Description,
+		},
+	}
+	if span.Parent().HasSpanID() {
+		converted.ParentSpanID = span.Parent().SpanID().String()
+	}
+	for _, event := range span.Events() {
+		converted.Events = append(converted.Events, otlpEvent{
+			Name:         event.Name,
+			TimeUnixNano: strconv.FormatInt(event.Time.UnixNano(), 10),
+			Attributes:   otlpAttributes(event.Attributes),
+		})
+	}
+	return converted
+}
+
+func otlpAttributes(attributes []attribute.KeyValue) []otlpAttribute {
+	converted := make([]otlpAttribute, len(attributes))
+	for index, keyValue := range attributes {
+		converted[index] = otlpAttribute{Key: string(keyValue.Key), Value: otlpValueOf(keyValue.Value)}
+	}
+	return converted
+}
+
+func otlpValueOf(value attribute.Value) otlpValue {
+	switch value.Type() {
+	case attribute.BOOL:
+		boolValue := value.AsBool()
+		return otlpValue{BoolValue: &boolValue}
+	case attribute.INT64:
+		intValue := strconv.FormatInt(value.AsInt64(), 10)
+		return otlpValue{IntValue: &intValue}
+	case attribute.FLOAT64:
+		doubleValue := value.AsFloat64()
+		return otlpValue{DoubleValue: &doubleValue}
+	case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE, attribute.STRINGSLICE:
+		return otlpValue{ArrayValue: &otlpArrayValue{Values: otlpSliceValues(value)}}
+	}
+	stringValue := value.Emit()
+	return otlpValue{StringValue: &stringValue}
+}
+
+func otlpSliceValues(value attribute.Value) []otlpValue {
+	var values []otlpValue
+	switch value.Type() {
+	case attribute.BOOLSLICE:
+		for _, item := range value.AsBoolSlice() {
+			values = append(values, otlpValueOf(attribute.BoolValue(item)))
+		}
+	case attribute.INT64SLICE:
+		for _, item := range value.AsInt64Slice() {
+			values = append(values, otlpValueOf(attribute.Int64Value(item)))
+		}
+	case attribute.FLOAT64SLICE:
+		for _, item := range value.AsFloat64Slice() {
+			values = append(values, otlpValueOf(attribute.Float64Value(item)))
+		}
+	case attribute.STRINGSLICE:
+		for _, item := range value.AsStringSlice() {
+			values = append(values, otlpValueOf(attribute.StringValue(item)))
+		}
+	}
+	return values
+}
@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters accepted by Config.Exporter.
+const (
+	ExporterNone     = "none"
+	ExporterStdout   = "stdout"
+	ExporterOTLPFile = "otlp-file"
+)
+
+// ServiceName identifies the data plane in exported spans.
+const ServiceName = "nexus-data-plane"
+
+// Config selects where spans are exported.
+type Config struct {
+	// Exporter is one of the Exporter constants; empty means none.
+	Exporter string
+	// File is the path ExporterOTLPFile appends to.
+	File string
+}
+
+// Setup installs the W3C trace-context propagator and, unless the exporter
+// is none, a global tracer provider exporting through it. The returned
+// function flushes pending spans and closes the exporter.
+func Setup(config Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	exporter, err := newExporter(config)
+	if err != nil || exporter == nil {
+		return func(context.Context) error { return nil }, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newExporter(config Config) (sdktrace.SpanExporter, error) {
+	switch config.Exporter {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New()
+	case ExporterOTLPFile:
+		return NewFileExporter(config.File)
+	}
+	return nil, fmt.Errorf("unknown tracing exporter %q (want %q, %q or %q)",
+		config.Exporter, ExporterNone, ExporterStdout, ExporterOTLPFile)
+}
+
+// TraceID returns the hex trace ID of the span in ctx, or "" when ctx
+// carries no valid trace context.
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
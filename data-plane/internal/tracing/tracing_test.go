@@ -0,0 +1,112 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/oragazz0/nexus-event-stream/data-plane/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type exportedFile struct {
+	ResourceSpans []struct {
+		ScopeSpans []struct {
+			Scope struct {
+				Name string `json:"name"`
+			} `json:"scope"`
+			Spans []struct {
+				TraceID      string `json:"traceId"`
+				ParentSpanID string `json:"parentSpanId"`
+				Name         string `json:"name"`
+				Kind         int    `json:"kind"`
+				Attributes   []struct {
+					Key   string         `json:"key"`
+					Value map[string]any `json:"value"`
+				} `json:"attributes"`
+				Status struct {
+					Code int `json:"code"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func TestFileExporter_WritesOTLPJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	exporter, err := tracing.NewFileExporter(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "process", trace.WithSpanKind(trace.SpanKindConsumer))
+	_, child := tracer.Start(ctx, "apply", trace.WithAttributes(attribute.Int64("messaging.kafka.offset", 41)))
+	child.SetStatus(codes.Error, "redis down")
+	child.End()
+	parent.End()
+	if err := provider.Shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected shutdown error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	var first exportedFile
+	if err := decoder.Decode(&first); err != nil {
+		t.Fatalf("failed to decode first line: %v", err)
+	}
+	span := first.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if span.Name != "apply" || span.ParentSpanID == "" {
+		t.Errorf("expected the child span with a parent first, got %+v", span)
+	}
+	if span.TraceID != parent.SpanContext().TraceID().String() {
+		t.Errorf("expected trace %s, got %s", parent.SpanContext().TraceID(), span.TraceID)
+	}
+	if span.Status.Code != 2 {
+		t.Errorf("expected OTLP error status 2, got %d", span.Status.Code)
+	}
+	if len(span.Attributes) != 1 || span.Attributes[0].Value["intValue"] != "41" {
+		t.Errorf("expected offset as an OTLP intValue, got %+v", span.Attributes)
+	}
+	var second exportedFile
+	if err := decoder.Decode(&second); err != nil {
+		t.Fatalf("failed to decode second line: %v", err)
+	}
+	if kind := second.ResourceSpans[0].ScopeSpans[0].Spans[0].Kind; kind != 5 {
+		t.Errorf("expected OTLP consumer kind 5, got %d", kind)
+	}
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := tracing.Setup(tracing.Config{Exporter: "jaeger"})
+
+	if err == nil {
+		t.Fatal("expected error for an unknown exporter, got nil")
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	if got := tracing.TraceID(ctx); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected trace id from context, got %q", got)
+	}
+	if got := tracing.TraceID(context.Background()); got != "" {
+		t.Errorf("expected empty trace id without a span, got %q", got)
+	}
+}